@@ -0,0 +1,91 @@
+package args
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// Callback IDs of the global and message shortcuts set up in the app
+// config.
+const (
+	shortcutMenu   = "angrms_menu"
+	shortcutCreate = "angrms_create"
+	shortcutPlay   = "angrms_play"
+	shortcutStats  = "angrms_stats"
+)
+
+// interactionUser fills in the user's name, which shortcut payloads leave
+// out.
+func (app *App) interactionUser(req slack.InteractionCallback) slack.InteractionCallback {
+	if req.User.Name != "" {
+		return req
+	}
+
+	user, err := app.api.GetUserInfo(req.User.ID)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		return req
+	}
+
+	req.User.Name = user.Name
+	return req
+}
+
+// BlockAction handles buttons pressed outside a modal, in channel messages
+// or the App Home, by their action ID.
+func (app *App) BlockAction(req slack.InteractionCallback, res http.ResponseWriter) {
+	if len(req.ActionCallback.BlockActions) == 0 {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	req = app.interactionUser(req)
+	actionID := req.ActionCallback.BlockActions[0].ActionID
+
+	if strings.HasPrefix(actionID, "continue-") {
+		app.StartGame(req, res)
+		return
+	}
+
+	switch actionID {
+	case "play-game":
+		app.StartGame(req, res)
+	case "menu":
+		app.openMainMenu(req)
+	case "create", "play", "play-private", "stats", "tips":
+		app.ParseMenu(req, res)
+	default:
+		res.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// Shortcut opens the flow a global or message shortcut asks for.
+func (app *App) Shortcut(req slack.InteractionCallback, res http.ResponseWriter) {
+	req = app.interactionUser(req)
+
+	switch req.CallbackID {
+	case shortcutMenu:
+		app.openMainMenu(req)
+	case shortcutCreate:
+		app.createGame(req.User.Name, req.TriggerID)
+	case shortcutPlay:
+		req.ActionCallback.BlockActions = []*slack.BlockAction{{ActionID: "play"}}
+		app.ParseMenu(req, res)
+	case shortcutStats:
+		app.StatsInitView(res, req.TriggerID, false)
+	default:
+		res.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (app *App) openMainMenu(req slack.InteractionCallback) {
+	apiRes, err := app.api.OpenView(req.TriggerID, app.mainMenuView(req.User.Name))
+
+	if err != nil {
+		fmt.Printf("%+v", apiRes)
+	}
+}
@@ -0,0 +1,112 @@
+package args
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	announceCreated = "created"
+	announceSolved  = "solved"
+	announceExpired = "expired"
+)
+
+// announceEvents parses ANNOUNCEMENTS, a comma separated list of events.
+// Every event is announced when it is empty.
+func announceEvents(value string) map[string]bool {
+	if strings.TrimSpace(value) == "" {
+		value = strings.Join([]string{announceCreated, announceSolved, announceExpired}, ",")
+	}
+
+	events := make(map[string]bool)
+	for _, event := range strings.Split(value, ",") {
+		events[strings.ToLower(strings.TrimSpace(event))] = true
+	}
+
+	return events
+}
+
+// announced reports whether anyone else should hear about event on game.
+// Private games, challenges and team games are never announced.
+func (app *App) announced(event string, game Game) bool {
+	if app.announceChannel == "" && app.webhook == "" {
+		return false
+	}
+
+	return app.announcements[event] && !game.Private && game.Challenge == nil && game.Shared == nil
+}
+
+func playButton(game Game) *slack.ButtonBlockElement {
+	buttonText := slack.NewTextBlockObject("plain_text", "Play", false, false)
+	button := slack.NewButtonBlockElement("play-game", game.Id.Hex(), buttonText)
+	button.Style = slack.StylePrimary
+
+	return button
+}
+
+func (app *App) announce(game Game, message string) {
+	messageBlock := slack.NewTextBlockObject("mrkdwn", message, false, false)
+	blocks := []slack.Block{
+		slack.NewSectionBlock(messageBlock, nil, nil),
+		slack.NewActionBlock("play-"+game.Id.Hex(), playButton(game)),
+	}
+
+	var err error
+
+	if app.announceChannel != "" {
+		_, _, err = app.api.PostMessage(app.announceChannel, slack.MsgOptionBlocks(blocks...), slack.MsgOptionText(message, false))
+	} else {
+		err = slack.PostWebhook(app.webhook, &slack.WebhookMessage{
+			Text:   message,
+			Blocks: &slack.Blocks{BlockSet: blocks},
+		})
+	}
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+	}
+}
+
+func (app *App) announceCreatedGame(game Game) {
+	if !app.announced(announceCreated, game) {
+		return
+	}
+
+	_, _, creator := getUser(game.User)
+	message := ":new: *" + creator + "* created an Angrms game: *" + strings.ToUpper(game.Letters) + "* - " + strconv.Itoa(len(game.Words)) + " words to find."
+	if !game.ExpiresAt.IsZero() {
+		message += "  Playable until " + expiryText(game.ExpiresAt) + "."
+	}
+
+	app.announce(game, message)
+}
+
+// announceFirstSolve is called after user solves game, where game was read
+// before their name went on the leaderboard.
+func (app *App) announceFirstSolve(game Game, user string) {
+	if len(game.Leaderboard) > 0 || !app.announced(announceSolved, game) {
+		return
+	}
+
+	_, _, creator := getUser(game.User)
+	_, _, solver := getUser(user)
+	message := ":trophy: *" + solver + "* was the first to solve " + creator + "'s game *" + strings.ToUpper(game.Letters) + "*!  Think you can too?"
+
+	app.announce(game, message)
+}
+
+func (app *App) announceExpiredGames(games []Game) {
+	for _, game := range games {
+		if !app.announced(announceExpired, game) {
+			continue
+		}
+
+		_, _, creator := getUser(game.User)
+		message := ":hourglass: " + creator + "'s game *" + strings.ToUpper(game.Letters) + "* has expired.  " + strconv.Itoa(len(game.Leaderboard)) + " solved it.  See what you missed!"
+
+		app.announce(game, message)
+	}
+}
@@ -0,0 +1,32 @@
+package args
+
+import (
+	"os"
+
+	"github.com/slack-go/slack"
+)
+
+// App handles Angrms commands and interactions, keeping games in store and
+// talking to Slack through api.
+type App struct {
+	store Store
+	api   *slack.Client
+
+	// Announcements go to announceChannel through the API when it is set,
+	// and to the webhook otherwise. announcements picks which are posted.
+	announceChannel string
+	webhook         string
+	announcements   map[string]bool
+}
+
+// New reads the rest of its settings from the environment, so it has to be
+// called after .env is loaded.
+func New(store Store, api *slack.Client) *App {
+	return &App{
+		store:           store,
+		api:             api,
+		announceChannel: os.Getenv("ANNOUNCE_CHANNEL"),
+		webhook:         os.Getenv("WEBHOOK"),
+		announcements:   announceEvents(os.Getenv("ANNOUNCEMENTS")),
+	}
+}
@@ -1,7 +1,6 @@
 package args
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -12,20 +11,18 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/slack-go/slack"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
 	"gitlab.sweetwater.com/mike_mayo/slackbot/slices"
-	"gitlab.sweetwater.com/mike_mayo/slackbot/util"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 var err = godotenv.Load(".env")
-var api = slack.New(os.Getenv("OAUTH_TOKEN"), slack.OptionDebug(true))
 var nameSeparator = os.Getenv("NAME_SEPARATOR")
-var client = util.MongoClient().Database("slack")
 
 type Leaderboard struct {
-	User string    `bson:"user,omitempty"`
-	Date time.Time `bson:"date,omitempty"`
+	User     string    `bson:"user,omitempty"`
+	Date     time.Time `bson:"date,omitempty"`
+	Assisted bool      `bson:"assisted,omitempty"`
 }
 
 type Metadata struct {
@@ -44,6 +41,16 @@ type Game struct {
 	Letters     string             `bson:"letters"`
 	Private     bool               `bson:"private,omitempty"`
 	Expiration  string             `bson:"expiration,omitempty"`
+	Team        string             `bson:"team,omitempty"`
+	Rules       engine.Rules       `bson:"rules"`
+	Mode        string             `bson:"mode,omitempty"`
+	Daily       bool               `bson:"daily,omitempty"`
+	ExpiresAt   time.Time          `bson:"expiresAt,omitempty"`
+	ClosedAt    time.Time          `bson:"closedAt,omitempty"`
+	Scores      []Score            `bson:"scores,omitempty"`
+	Challenge   *Challenge         `bson:"challenge,omitempty"`
+	Shared      *SharedGame        `bson:"shared,omitempty"`
+	Sprint      int                `bson:"sprint,omitempty"`
 }
 
 type GameOption struct {
@@ -52,34 +59,45 @@ type GameOption struct {
 	UsersSolved int
 }
 
-func CheckArgs(res http.ResponseWriter, command slack.SlashCommand) {
+func (app *App) CheckArgs(res http.ResponseWriter, command slack.SlashCommand) {
 	args := strings.Fields(command.Text)
 
 	if len(args) == 0 {
-		mainMenu(res, command)
+		app.mainMenu(res, command)
 	} else {
 		switch args[0] {
 		case "create":
-			createGame(command.UserName, command.TriggerID)
+			if len(args) > 1 && args[1] == "random" {
+				app.createRandomGame(res, command, args[2:])
+				return
+			}
+
+			app.createGame(command.UserName, command.TriggerID)
+		case "challenge":
+			app.createChallenge(res, command, args[1:])
+		case "team":
+			app.createSharedGame(res, command, args[1:])
 		case "find":
-			findGame(res, command)
+			app.findGame(res, command)
 		case "stats":
-			StatsInitView(res, command.TriggerID, false)
+			app.StatsInitView(res, command.TriggerID, false)
 		case "instructions", "rules", "tips":
-			Instructions(command.TriggerID, res, false)
+			app.Instructions(command.TriggerID, res, false)
 		default:
-			res.Write([]byte("Only the following commands are available:\n`/angrms create`\n`/angrms play`\n`/angrms stats`\n`/angrms find`"))
+			res.Write([]byte("Only the following commands are available:\n`/angrms create`\n`/angrms create random [n]` (n is " + strconv.Itoa(minRandomLetters) + " to " + strconv.Itoa(slices.MaxLetters) + " letters)\n`/angrms challenge @user [letters]`\n`/angrms team [letters]`\n`/angrms play`\n`/angrms stats`\n`/angrms find`"))
 		}
 	}
 }
 
-func getGames(res http.ResponseWriter, filter bson.E) []Game {
-	gamesColl := client.Collection("games")
+// getGames returns the newest 10 games matching query.
+func (app *App) getGames(query GameQuery) []Game {
+	query.Limit = 10
+	query.Sort = SortNewest
+	games, err := app.store.FindGames(query)
 
-	a := util.GetDocs(gamesColl, filter)
-
-	var games []Game
-	a.All(context.TODO(), &games)
+	if err != nil {
+		fmt.Printf("%+v", err)
+	}
 
 	return games
 }
@@ -87,7 +105,7 @@ func getGames(res http.ResponseWriter, filter bson.E) []Game {
 func getActiveGames(games []Game, user string) []Game {
 	var spliced []Game
 	for _, game := range games {
-		if game.Active && !game.Private {
+		if game.playable(time.Now()) && !game.Private {
 			if len(game.Leaderboard) == 0 {
 				spliced = append(spliced, game)
 				continue
@@ -126,6 +144,22 @@ func updateModal(payload slack.InteractionCallback) slack.ModalViewRequest {
 	return view
 }
 
+// onModal reports whether the action came from a modal that a new view can
+// be pushed onto, rather than a message, the App Home or a shortcut.
+func onModal(req slack.InteractionCallback) bool {
+	return req.View.ID != "" && req.View.Type != slack.VTHomeTab
+}
+
+// showView pushes view onto the current modal, or opens it when there is no
+// modal to push onto.
+func (app *App) showView(req slack.InteractionCallback, view slack.ModalViewRequest) (*slack.ViewResponse, error) {
+	if !onModal(req) {
+		return app.api.OpenView(req.TriggerID, view)
+	}
+
+	return app.api.PushView(req.TriggerID, view)
+}
+
 func getUser(userName string) (string, string, string) {
 	user := strings.Split(userName, nameSeparator)
 	titleCase := strings.ToUpper(strings.Split(user[0], "")[0])
@@ -157,10 +191,11 @@ func createGameModal(user string) slack.ModalViewRequest {
 	inputPlaceholder := slack.NewTextBlockObject("plain_text", "rstlne", false, false)
 	inputBlock := slack.NewPlainTextInputBlockElement(inputPlaceholder, "letters")
 	input := slack.NewInputBlock("letters", inputLabel, nil, inputBlock)
+	input.Optional = true
 
-	expirationMessage := "How long would you like this game to be active? Hint: use a number followed by a time unit. If you don't want to set a time limit, leave this field blank"
+	expirationMessage := "How long would you like this game to be active? If you don't want to set a time limit, leave this field blank"
 	expirationLabel := slack.NewTextBlockObject("plain_text", expirationMessage, false, false)
-	expirationHint := slack.NewTextBlockObject("plain_text", "Supported units: m = minutes, h = hours, d = days", false, false)
+	expirationHint := slack.NewTextBlockObject("plain_text", "Durations like 30m, 1d12h or 2w, or times like end of day or friday 5pm", false, false)
 	expirationPlaceholder := slack.NewTextBlockObject("plain_text", "3d", false, false)
 	expirationInput := slack.NewPlainTextInputBlockElement(expirationPlaceholder, "expiration")
 	expirationBlock := slack.NewInputBlock("expiration", expirationLabel, expirationHint, expirationInput)
@@ -178,18 +213,21 @@ func createGameModal(user string) slack.ModalViewRequest {
 			headerSection,
 			slack.NewDividerBlock(),
 			input,
-			expirationBlock,
-			privateInput,
+			surpriseInput(),
 		},
 	}
 
+	modal.Blocks.BlockSet = append(modal.Blocks.BlockSet, modeInput())
+	modal.Blocks.BlockSet = append(modal.Blocks.BlockSet, ruleInputs()...)
+	modal.Blocks.BlockSet = append(modal.Blocks.BlockSet, expirationBlock, sprintInput(), privateInput)
+
 	return modal
 }
 
-func createGame(user string, triggerId string) {
+func (app *App) createGame(user string, triggerId string) {
 	modal := createGameModal(user)
 
-	apiRes, err := api.OpenView(triggerId, modal)
+	apiRes, err := app.api.OpenView(triggerId, modal)
 
 	if err != nil {
 		fmt.Printf("%+v", apiRes)
@@ -197,109 +235,171 @@ func createGame(user string, triggerId string) {
 	}
 }
 
-func removeDuplicates(letters string) string {
-	stringSet := make(map[string]bool, 0)
-	letterSet := ""
+func submissionErrors(res http.ResponseWriter, messages map[string]string) {
+	errors := slack.NewErrorsViewSubmissionResponse(messages)
 
-	for _, letter := range strings.Split(letters, "") {
-		if !stringSet[letter] {
-			stringSet[letter] = true
-			letterSet += letter
-		}
+	jsonString, _ := json.Marshal(errors)
+	res.Header().Add("Content-Type", "application/json")
+	res.Write(jsonString)
+}
+
+func (app *App) insertGame(game Game) error {
+	game.Active = true
+	game.Leaderboard = make([]Leaderboard, 0)
+	game.Date = time.Now()
+
+	_, err := app.store.InsertGame(game)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
 	}
 
-	return letterSet
+	return err
 }
 
-func getExpiry(chosenExpiry string, creation time.Time) bool {
-	length := len(chosenExpiry)
-	unit := strings.ToLower(chosenExpiry[length-2 : length-1])
-	amount, _ := strconv.Atoi(chosenExpiry[:length-2])
-	timeSince := time.Since(creation)
-	expired := false
+// newGame builds a game from team's dictionary through the engine, the way
+// every create path does, with a fresh ID and the fields the engine fills in.
+// On error the game still has the letters that were tried.
+func newGame(team string, options engine.CreateOptions) (Game, error) {
+	dictionary, err := slices.ForTeam(team)
 
-	switch unit {
-	case "m":
-		if time.Duration.Minutes(timeSince) > float64(amount) {
-			expired = true
-		}
-	case "h":
-		if time.Duration.Hours(timeSince) > float64(amount) {
-			expired = true
-		}
-	case "d":
-		if time.Duration.Hours(timeSince) > float64(amount*24) {
-			expired = true
-		}
+	if err != nil {
+		return Game{}, err
+	}
+
+	if options.Random > 0 && options.MinWords == 0 && options.MaxWords == 0 {
+		options.MinWords = randomMinWords
+		options.MaxWords = randomMaxWords
+	}
+
+	created, err := engine.CreateGame(dictionary, options, time.Now().In(workspaceLocation))
+
+	if err != nil {
+		return Game{Letters: created.Letters}, err
 	}
 
-	return expired
+	return Game{
+		Id:         primitive.NewObjectID(),
+		Words:      created.Words,
+		Letters:    created.Letters,
+		Team:       team,
+		Rules:      created.Rules,
+		Mode:       created.Mode,
+		Expiration: options.Expiration,
+		ExpiresAt:  created.ExpiresAt,
+	}, nil
 }
 
-func SaveNewGame(payload slack.InteractionCallback, res http.ResponseWriter) {
+func (app *App) SaveNewGame(payload slack.InteractionCallback, res http.ResponseWriter) {
 	letters := payload.View.State.Values["letters"]["letters"].Value
 	expiration := payload.View.State.Values["expiration"]["expiration"].Value
 	options := payload.View.State.Values["private"]["private"].SelectedOptions
+	surprise := payload.View.State.Values["surprise"]["surprise"].SelectedOption.Value
+	sprint, _ := strconv.Atoi(payload.View.State.Values["sprint"]["sprint"].SelectedOption.Value)
 	private := false
 
 	if len(options) > 0 {
 		private = true
 	}
 
-	letters = removeDuplicates(letters)
+	mode := engine.ModeReuse
+	if len(payload.View.State.Values["mode"]["mode"].SelectedOptions) > 0 {
+		mode = engine.ModeAnagram
+	}
+
 	user := string(payload.User.Name)
-	words := slices.FindWordsWithLetters(letters)
+	rules, ruleErrors := parseRules(payload.View.State.Values)
+
+	if len(ruleErrors) > 0 {
+		submissionErrors(res, ruleErrors)
+		return
+	}
+
+	random, _ := strconv.Atoi(surprise)
+	game, err := newGame(payload.Team.ID, engine.CreateOptions{
+		Letters:    letters,
+		Random:     random,
+		Mode:       mode,
+		Rules:      rules,
+		Expiration: expiration,
+	})
+
+	if err != nil {
+		var messages map[string]string
+
+		switch err {
+		case engine.ErrNoLetters:
+			messages = map[string]string{"letters": "Choose some letters, or pick a number of letters to be surprised with"}
+		case engine.ErrNoWords:
+			messages = map[string]string{"letters": "No words found with letters '" + game.Letters + "'!  Try another combination!"}
+		case engine.ErrCenterLetter:
+			messages = map[string]string{"centerLetter": "'" + rules.CenterLetter + "' isn't one of your letters"}
+		case engine.ErrNoRandomLetters:
+			messages = map[string]string{"surprise": "Couldn't find " + surprise + " letters that make between " + strconv.Itoa(randomMinWords) + " and " + strconv.Itoa(randomMaxWords) + " words.  Try a different number!"}
+		case engine.ErrTooManyLetters:
+			messages = map[string]string{"letters": "Anagram mode and required letters work with up to " + strconv.Itoa(slices.MaxLetters) + " different letters.  Try fewer!"}
+		case engine.ErrExpiry:
+			messages = map[string]string{"expiration": "Try something like 30m, 1d12h, 2w, end of day or friday 5pm"}
+		default:
+			fmt.Printf("%+v", err)
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		submissionErrors(res, messages)
+		return
+	}
+
+	letters = game.Letters
+	words := game.Words
+	expiresAt := game.ExpiresAt
 
 	view := updateModal(payload)
 
-	if len(words) == 0 {
-		messageMap := make(map[string]string)
-		messageMap["letters"] = "No words found with letters '" + letters + "'!  Try another combination!"
-		errors := slack.NewErrorsViewSubmissionResponse(messageMap)
+	message := "You created a game that has " + strconv.Itoa(len(words)) + " words to find! 🚀🚀🚀"
+	if surprise != "" {
+		message = "You created a game with the letters " + strings.ToUpper(letters) + " that has " + strconv.Itoa(len(words)) + " words to find! 🚀🚀🚀"
+	}
 
-		jsonString, _ := json.Marshal(errors)
-		res.Header().Add("Content-Type", "application/json")
-		res.Write(jsonString)
-	} else {
-		message := "You created a game that has " + strconv.Itoa(len(words)) + " words to find! 🚀🚀🚀"
-		textBlock := slack.NewTextBlockObject("plain_text", message, false, false)
-		section := slack.NewSectionBlock(textBlock, nil, nil)
+	textBlock := slack.NewTextBlockObject("plain_text", message, false, false)
+	section := slack.NewSectionBlock(textBlock, nil, nil)
 
-		blocks := slack.Blocks{
-			BlockSet: []slack.Block{
-				section,
-			},
-		}
+	blocks := slack.Blocks{
+		BlockSet: []slack.Block{
+			section,
+		},
+	}
 
-		view.Blocks = blocks
-		view.Submit = nil
-		view.ClearOnClose = true
-		view.Close.Text = "Close"
+	if !expiresAt.IsZero() {
+		expiryMessage := "It will be playable until " + expiryText(expiresAt) + "."
+		expiryBlock := slack.NewTextBlockObject("plain_text", expiryMessage, false, false)
+		blocks.BlockSet = append(blocks.BlockSet, slack.NewSectionBlock(expiryBlock, nil, nil))
+	}
 
-		viewRes := slack.NewUpdateViewSubmissionResponse(&view)
+	if sprint > 0 {
+		sprintMessage := "Each player gets one " + sprintLength(sprint) + " sprint at it."
+		sprintBlock := slack.NewTextBlockObject("plain_text", sprintMessage, false, false)
+		blocks.BlockSet = append(blocks.BlockSet, slack.NewSectionBlock(sprintBlock, nil, nil))
+	}
 
-		jsonString, _ := json.Marshal(viewRes)
+	view.Blocks = blocks
+	view.Submit = nil
+	view.ClearOnClose = true
+	view.Close.Text = "Close"
 
-		res.Header().Add("Content-Type", "application/json")
-		res.Write(jsonString)
+	viewRes := slack.NewUpdateViewSubmissionResponse(&view)
 
-		var game Game
+	jsonString, _ := json.Marshal(viewRes)
 
-		game.User = user
-		game.Active = true
-		game.Leaderboard = make([]Leaderboard, 0)
-		game.Words = words
-		game.Date = time.Now()
-		game.Letters = letters
-		game.Private = private
-		game.Expiration = expiration
+	res.Header().Add("Content-Type", "application/json")
+	res.Write(jsonString)
 
-		insert, err := client.Collection("games").InsertOne(context.TODO(), game)
+	game.User = user
+	game.Private = private
+	game.Sprint = sprint
 
-		if err != nil {
-			fmt.Printf("%+v", insert)
-			fmt.Printf("%+v", err)
-		}
+	if err := app.insertGame(game); err == nil {
+		app.announceCreatedGame(game)
 	}
 }
 
@@ -316,6 +416,13 @@ func addGameOptions(offset int, games []Game) slack.ActionBlock {
 		optionText := slack.NewTextBlockObject("mrkdwn", message, false, false)
 
 		descriptionText := fullname + " - " + strconv.Itoa(len(game.Words)) + " words"
+		if game.Daily {
+			descriptionText = "Daily puzzle " + game.Date.Local().Format("Jan 2") + " - " + strconv.Itoa(len(game.Words)) + " words"
+		}
+
+		if mode := modeLabel(game.Mode); mode != "" {
+			descriptionText += " - " + mode
+		}
 		description := slack.NewTextBlockObject("plain_text", descriptionText, false, false)
 
 		optionBlock := slack.NewOptionBlockObject(string(gameID), optionText, description)
@@ -337,14 +444,66 @@ func gameInput(gameId string, blockId string) *slack.InputBlock {
 	return input
 }
 
-func StartGame(req slack.InteractionCallback, res http.ResponseWriter) {
+func (app *App) getGame(gameId primitive.ObjectID) Game {
+	game, err := app.store.GetGame(gameId)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	return game
+}
+
+// selectedGameID reads the game ID from either a game list radio button or a
+// button whose value is the game ID.
+func selectedGameID(action *slack.BlockAction) string {
+	if action.SelectedOption.Value != "" {
+		return action.SelectedOption.Value
+	}
+
+	return action.Value
+}
+
+func lettersSection(game Game) *slack.SectionBlock {
+	letters := "*" + strings.ToUpper(game.Letters) + "*"
+	if game.Mode == engine.ModeAnagram {
+		letters += "\nAnagram mode: each letter can only be used as many times as it appears"
+	}
+
+	if description := describeRules(game.Rules); description != "" {
+		letters += "\n" + description
+	}
+
+	if game.Sprint > 0 {
+		letters += "\nSprint: one " + sprintLength(game.Sprint) + " attempt per player"
+	}
+
+	letterBlock := slack.NewTextBlockObject("mrkdwn", letters, false, false)
+	return slack.NewSectionBlock(letterBlock, nil, revealButton())
+}
+
+func wordsLeftSection(game Game, progress Progress) *slack.SectionBlock {
+	result := engine.Progress(game.Puzzle(), progress.Words, progress.HintsUsed)
+
+	headerText := strconv.Itoa(result.Remaining) + " words left!  Score: " + strconv.Itoa(result.Points)
+	if sprint := sprintText(progress); sprint != "" {
+		headerText += "\n" + sprint
+	}
+
+	if hint := hintText(progress); hint != "" {
+		headerText += "\n" + hint
+	}
+
+	header := slack.NewTextBlockObject("mrkdwn", headerText, false, false)
+	return slack.NewSectionBlock(header, nil, hintButton())
+}
+
+func (app *App) StartGame(req slack.InteractionCallback, res http.ResponseWriter) {
 	var view slack.ModalViewRequest
 	view.CallbackID = "play"
 
-	var meta Metadata
-	selectedGame := req.ActionCallback.BlockActions[0].SelectedOption
-	// meta.GameID = selectedGame.Value
-	gameId, err := primitive.ObjectIDFromHex(selectedGame.Value)
+	selectedGame := selectedGameID(req.ActionCallback.BlockActions[0])
+	gameId, err := primitive.ObjectIDFromHex(selectedGame)
 
 	if err != nil {
 		fmt.Printf("%+v", err)
@@ -352,10 +511,52 @@ func StartGame(req slack.InteractionCallback, res http.ResponseWriter) {
 		return
 	}
 
-	splitDescriptiom := strings.Split(selectedGame.Description.Text, " - ")
-	creator := splitDescriptiom[0]
-	totalWords := strings.Split(splitDescriptiom[1], " words")[0]
-	view.PrivateMetadata, err = json.Marshal(meta)
+	game := app.getGame(gameId)
+	progress := app.playerProgress(game, req.User.Name)
+	wordsFound := progress.found()
+
+	if !game.canPlay(req.User.Name) {
+		res.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if !game.playable(time.Now()) || progress.Revealed {
+		message := gameOverMessage(game)
+		if progress.Revealed {
+			message = "You've already revealed the answers to this game."
+		}
+
+		apiRes, err := app.showView(req, summaryView(game, wordsFound, "Game over", message))
+
+		if err != nil {
+			fmt.Println(err, apiRes)
+		}
+		return
+	}
+
+	if game.Sprint > 0 {
+		progress, err = app.startSprint(game, req.User.Name)
+
+		if err != nil {
+			fmt.Printf("%+v", err)
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		wordsFound = progress.found()
+
+		if progress.sprintOver(time.Now()) {
+			apiRes, err := app.showView(req, summaryView(game, wordsFound, "Time's up!", sprintOverMessage(game)))
+
+			if err != nil {
+				fmt.Println(err, apiRes)
+			}
+			return
+		}
+	}
+
+	_, _, creator := getUser(game.User)
+	view.PrivateMetadata = selectedGame
 
 	if len(creator) > 18 {
 		creator = strings.Split(creator, " ")[0]
@@ -366,30 +567,21 @@ func StartGame(req slack.InteractionCallback, res http.ResponseWriter) {
 	view.Title = slack.NewTextBlockObject("plain_text", titleMessage, false, false)
 	view.Close = slack.NewTextBlockObject("plain_text", "Cancel", false, false)
 
-	headerText := totalWords + " words left!"
-	header := slack.NewTextBlockObject("plain_text", headerText, false, false)
-	headerSection := slack.NewSectionBlock(header, nil, nil)
-
-	found := client.Collection("games").FindOne(context.TODO(), bson.D{{"_id", gameId}})
-
-	var game Game
-	found.Decode(&game)
-
-	letters := "*" + strings.ToUpper(game.Letters) + "*"
-	letterBlock := slack.NewTextBlockObject("mrkdwn", letters, false, false)
-	letterSection := slack.NewSectionBlock(letterBlock, nil, nil)
-
-	input := gameInput(selectedGame.Value, "guess")
+	input := gameInput(selectedGame, "guess")
 
 	view.Blocks = slack.Blocks{
 		BlockSet: []slack.Block{
-			letterSection,
-			headerSection,
+			lettersSection(game),
+			wordsLeftSection(game, progress),
 			input,
 		},
 	}
 
-	apiRes, err := api.PushView(req.TriggerID, view)
+	if len(wordsFound) > 0 {
+		view.Blocks.BlockSet = append(view.Blocks.BlockSet, foundWordsSection(wordsFound)...)
+	}
+
+	apiRes, err := app.showView(req, view)
 
 	if err != nil {
 		fmt.Println(err, apiRes)
@@ -397,20 +589,20 @@ func StartGame(req slack.InteractionCallback, res http.ResponseWriter) {
 	}
 }
 
+// foundWordsSection packs the found words into as few blocks as it can, so
+// big games stay under Slack's 100 block limit.
 func foundWordsSection(wordsFound []string) []slack.Block {
-	var blocks []slack.Block
 	message := slack.NewTextBlockObject("plain_text", "You found: ", false, false)
 	messageBlock := slack.NewSectionBlock(message, nil, nil)
 
-	blocks = append(blocks, []slack.Block{
+	blocks := []slack.Block{
 		slack.NewDividerBlock(),
 		messageBlock,
-	}...)
+	}
+	blocks = append(blocks, textSections(wordsFound)...)
 
-	for _, word := range wordsFound {
-		newWord := slack.NewTextBlockObject("plain_text", word, false, false)
-		newWordSection := slack.NewSectionBlock(newWord, nil, nil)
-		blocks = append(blocks, newWordSection)
+	if define := defineSelect(wordsFound); define != nil {
+		blocks = append(blocks, define)
 	}
 
 	return blocks
@@ -426,7 +618,23 @@ func alreadyGuessed(wordsFound []string, guess string) bool {
 	return false
 }
 
-func PlayGame(req slack.InteractionCallback, res http.ResponseWriter) {
+func (app *App) PlayGame(req slack.InteractionCallback, res http.ResponseWriter) {
+	if len(req.ActionCallback.BlockActions) > 0 {
+		action := req.ActionCallback.BlockActions[0]
+
+		switch action.ActionID {
+		case "define":
+			app.showDefinition(req, action.SelectedOption.Value)
+			return
+		case "hint":
+			app.giveHint(req, res)
+			return
+		case "reveal":
+			app.revealAnswers(req, res)
+			return
+		}
+	}
+
 	guess := strings.ToLower(req.View.State.Values["guess"]["letters"].Value)
 	blockId := "gues"
 
@@ -436,8 +644,8 @@ func PlayGame(req slack.InteractionCallback, res http.ResponseWriter) {
 	}
 	view := updateModal(req)
 
-	meta := strings.Split(req.View.PrivateMetadata, ",")
-	gameId, err := primitive.ObjectIDFromHex(meta[0])
+	gameHex := req.View.PrivateMetadata
+	gameId, err := primitive.ObjectIDFromHex(gameHex)
 
 	if err != nil {
 		fmt.Print("Could not convert hex string to mongo object id")
@@ -445,25 +653,68 @@ func PlayGame(req slack.InteractionCallback, res http.ResponseWriter) {
 		return
 	}
 
-	var game Game
-	client.Collection("games").FindOne(context.TODO(), bson.D{{"_id", gameId}}).Decode(&game)
+	game := app.getGame(gameId)
+
+	if !game.playable(time.Now()) {
+		app.gameOver(req, res, game)
+		return
+	}
 
 	user := req.User.Name
 
-	var wordsFound []string
-	if len(meta) > 1 {
-		wordsFound = meta[1:]
+	if !game.canPlay(user) {
+		res.WriteHeader(http.StatusForbidden)
+		return
 	}
+	progress := app.playerProgress(game, user)
+	wordsFound := progress.found()
 
-	incorrectGuess := true
-	guessed := alreadyGuessed(wordsFound, guess)
+	if progress.Revealed {
+		view := summaryView(game, wordsFound, "Game over", "You've already revealed the answers to this game.")
+		apiRes, err := app.api.UpdateView(view, "", req.Hash, req.View.ID)
 
-	if !guessed {
-		for _, word := range game.Words {
-			if guess == word {
-				wordsFound = append(wordsFound, word)
-				incorrectGuess = false
-				break
+		if err != nil {
+			fmt.Printf("%+v", apiRes)
+		}
+		return
+	}
+
+	if progress.sprintOver(time.Now()) {
+		app.timeUp(req, res, game, wordsFound)
+		return
+	}
+
+	result := engine.Guess(game.Puzzle(), wordsFound, guess, time.Now())
+
+	if result.Outcome == engine.Closed {
+		app.gameOver(req, res, game)
+		return
+	}
+
+	guessed := result.Outcome == engine.AlreadyGuessed
+	incorrectGuess := result.Outcome == engine.Incorrect
+
+	if result.Outcome == engine.Correct {
+		found := result.Found
+
+		// Someone else in the channel got there first.
+		if game.Shared != nil && !app.claimShared(game, user, found) {
+			game = app.getGame(gameId)
+			wordsFound = game.Shared.found()
+			guessed = true
+		} else {
+			progress.Words = append(progress.Words, found)
+			wordsFound = append(wordsFound, found.Word)
+
+			if game.Shared != nil {
+				app.refreshShared(game)
+			} else {
+				app.addFoundWord(gameId, user, found)
+				app.recordScore(game, user, progress)
+
+				if game.Challenge != nil {
+					app.claimFirst(game, user, found)
+				}
 			}
 		}
 	}
@@ -471,9 +722,9 @@ func PlayGame(req slack.InteractionCallback, res http.ResponseWriter) {
 	if guessed || incorrectGuess {
 		view.Blocks = req.View.Blocks
 		view.Blocks.BlockSet = req.View.Blocks.BlockSet[:2]
-		view.Blocks.BlockSet = append(view.Blocks.BlockSet, gameInput(meta[0], blockId))
+		view.Blocks.BlockSet = append(view.Blocks.BlockSet, gameInput(gameHex, blockId))
 		view.CallbackID = "play"
-		view.PrivateMetadata = req.View.PrivateMetadata
+		view.PrivateMetadata = gameHex
 
 		errorMessage := "*_" + strings.ToUpper(guess) + "_*"
 		if guessed {
@@ -488,12 +739,12 @@ func PlayGame(req slack.InteractionCallback, res http.ResponseWriter) {
 		view.Blocks.BlockSet = append(view.Blocks.BlockSet, errorSection)
 		view.Blocks.BlockSet = append(view.Blocks.BlockSet, foundWordsSection(wordsFound)...)
 
-		apiRes, err := api.UpdateView(view, view.ExternalID, req.Hash, req.View.ID)
+		apiRes, err := app.api.UpdateView(view, view.ExternalID, req.Hash, req.View.ID)
 
 		if err != nil {
 			fmt.Printf("%+v", apiRes)
 		}
-	} else if len(wordsFound) == len(game.Words) {
+	} else if engine.Solve(game.Puzzle(), wordsFound).Solved {
 		_, _, creator := getUser(game.User)
 		var view slack.ModalViewRequest
 		view.Title = slack.NewTextBlockObject("plain_text", "Solved!! 🎉🎉🎉", false, false)
@@ -502,6 +753,9 @@ func PlayGame(req slack.InteractionCallback, res http.ResponseWriter) {
 		view.Type = slack.ViewType("modal")
 
 		message := "Congrats, you found all the words in " + creator + "'s game! You will be added to this game's leaderboard :wink:.  Use the `/angrms stats` to check it out!"
+		if game.Shared != nil {
+			message = "You found the last word in " + creator + "'s team game! :tada:"
+		}
 		messageBlock := slack.NewTextBlockObject("mrkdwn", message, false, false)
 		sectionBlock := slack.NewSectionBlock(messageBlock, nil, nil)
 
@@ -511,20 +765,22 @@ func PlayGame(req slack.InteractionCallback, res http.ResponseWriter) {
 			},
 		}
 
-		apiRes, err := api.UpdateView(view, "", req.Hash, req.View.ID)
+		apiRes, err := app.api.UpdateView(view, "", req.Hash, req.View.ID)
 
 		if err != nil {
 			fmt.Printf("%+v", apiRes)
 		}
 
-		leaderboard := bson.D{{
-			"$addToSet", bson.D{{
-				"leaderboard", Leaderboard{
-					User: user,
-					Date: time.Now(),
-				},
-			}},
-		}}
+		// Team games are celebrated in the channel instead.
+		if game.Shared != nil {
+			return
+		}
+
+		leader := Leaderboard{
+			User:     user,
+			Date:     time.Now(),
+			Assisted: progress.HintsUsed > 0,
+		}
 
 		// userSolved := bson.D{{
 		// 	"$set", bson.D{{
@@ -532,31 +788,32 @@ func PlayGame(req slack.InteractionCallback, res http.ResponseWriter) {
 		// 	}}
 		// }}
 
-		_, err = client.Collection("games").UpdateByID(context.TODO(), game.Id, leaderboard)
+		app.markSolved(gameId, user)
+
+		if game.Challenge != nil {
+			app.finishChallenge(game)
+		}
+
+		err = app.store.AddLeader(game.Id, leader)
 
 		if err != nil {
 			fmt.Printf("%+v", err)
 			return
 		}
+
+		app.announceFirstSolve(game, user)
 	} else if len(wordsFound) > 0 {
-		view.PrivateMetadata = strings.Join(wordsFound, ",")
-		view.PrivateMetadata = meta[0] + "," + view.PrivateMetadata
+		view.PrivateMetadata = gameHex
 		view.CallbackID = "play"
 		view.Blocks = req.View.Blocks
 		view.Blocks.BlockSet = req.View.Blocks.BlockSet[:2]
-		view.Blocks.BlockSet = append(view.Blocks.BlockSet, gameInput(meta[0], blockId))
-
-		totalWords := strconv.Itoa(len(game.Words) - len(wordsFound))
+		view.Blocks.BlockSet = append(view.Blocks.BlockSet, gameInput(gameHex, blockId))
 
-		headerText := totalWords + " words left!"
-		header := slack.NewTextBlockObject("plain_text", headerText, false, false)
-		headerSection := slack.NewSectionBlock(header, nil, nil)
-
-		view.Blocks.BlockSet[1] = headerSection
+		view.Blocks.BlockSet[1] = wordsLeftSection(game, progress)
 
 		view.Blocks.BlockSet = append(view.Blocks.BlockSet, foundWordsSection(wordsFound)...)
 
-		apiRes, err := api.UpdateView(view, req.View.ExternalID, req.Hash, req.View.ID)
+		apiRes, err := app.api.UpdateView(view, req.View.ExternalID, req.Hash, req.View.ID)
 
 		if err != nil {
 			fmt.Printf("%+v", apiRes)
@@ -565,13 +822,18 @@ func PlayGame(req slack.InteractionCallback, res http.ResponseWriter) {
 	}
 }
 
-func findGameModal(res http.ResponseWriter, user string, private bool, offset int) (slack.ModalViewRequest, []Game) {
-	var filter bson.E
+func (app *App) findGameModal(res http.ResponseWriter, user string, private bool, offset int) (slack.ModalViewRequest, []Game) {
+	query := GameQuery{Active: true, Public: true, Solo: true}
 	if private {
-		filter = bson.E{"user", user}
+		query = GameQuery{Player: user, Solo: true}
 	}
 
-	games := getGames(res, filter)
+	games := app.getGames(query)
+
+	// Keep today's daily puzzle at the top, however many games are newer.
+	if !private {
+		games = dailyFirst(app.getGames(GameQuery{Active: true, Daily: true}), games)
+	}
 
 	firstname, _, _ := getUser(user)
 
@@ -587,7 +849,7 @@ func findGameModal(res http.ResponseWriter, user string, private bool, offset in
 	header := slack.NewTextBlockObject("mrkdwn", message, false, false)
 	headerSection := slack.NewSectionBlock(header, nil, nil)
 
-	gameOptions := addGameOptions(0, games)
+	gameOptions := addGameOptions(offset, games)
 
 	view.Blocks = slack.Blocks{
 		BlockSet: []slack.Block{
@@ -600,7 +862,7 @@ func findGameModal(res http.ResponseWriter, user string, private bool, offset in
 	return view, games
 }
 
-func findGame(res http.ResponseWriter, command slack.SlashCommand) {
+func (app *App) findGame(res http.ResponseWriter, command slack.SlashCommand) {
 	params := strings.Fields(command.Text)
 	var private bool
 	if len(params) < 2 {
@@ -608,7 +870,7 @@ func findGame(res http.ResponseWriter, command slack.SlashCommand) {
 	} else if params[1] == "private" {
 		private = true
 	}
-	view, games := findGameModal(res, command.UserName, private)
+	view, games := app.findGameModal(res, command.UserName, private, 0)
 
 	if len(games) == 0 {
 		message := "Could not find any games :cry:"
@@ -619,7 +881,7 @@ func findGame(res http.ResponseWriter, command slack.SlashCommand) {
 		}
 	}
 
-	apiRes, err := api.OpenView(command.TriggerID, view)
+	apiRes, err := app.api.OpenView(command.TriggerID, view)
 
 	if err != nil {
 		fmt.Printf("%+v", apiRes)
@@ -627,7 +889,7 @@ func findGame(res http.ResponseWriter, command slack.SlashCommand) {
 	}
 }
 
-func Instructions(triggerID string, res http.ResponseWriter, push bool) {
+func (app *App) Instructions(triggerID string, res http.ResponseWriter, push bool) {
 	var view slack.ModalViewRequest
 	view.Close = slack.NewTextBlockObject("plain_text", "Back", false, false)
 	view.Type = slack.ViewType("modal")
@@ -638,13 +900,13 @@ func Instructions(triggerID string, res http.ResponseWriter, push bool) {
 	}
 
 	createHeader := slack.NewHeaderBlock(slack.NewTextBlockObject("plain_text", "Creating a game", false, false))
-	createMessage := "1. Provide some letters to create the game with.\n\n2. Duplicate letters aren't necessary.  The game will use the supplied letters multiple times if it can.\n\n3. If you set an expiration on the game it will only be playable for that amount of time.\n\n4. Units for setting an expiration are `m`, `h`, and `d`.  At this time those are the only ones supported.  The unit is preceded by a number, so setting it to `30m`, for example, would make the game inactive after 30 minutes.\n\n5. If you mark a game as private it will only be playable by you.\n\n5. You will *_only_* be shown the amount of words that are created but *_not_* the words themselves."
+	createMessage := "1. Provide some letters to create the game with.\n\n2. Duplicate letters aren't necessary.  The game will use the supplied letters multiple times if it can.  If you pick anagram mode, repeat a letter to allow it more than once, so `aabt` allows two a's and one each of b and t.\n\n3. If you set an expiration on the game it will only be playable for that amount of time.\n\n4. An expiration can be a length of time made of numbers and the units `m`, `h`, `d` and `w`, so `30m` would make the game inactive after 30 minutes and `1d12h` after a day and a half.  You can also give a time, like `end of day`, `end of week`, `tomorrow 9am` or `friday 5pm`.\n\n5. If you mark a game as private it will only be playable by you.\n\n6. You can limit how short or long the words are.  Choosing a required letter changes the game: words can use any of your letters, but every one of them has to contain the required letter.\n\n7. You will *_only_* be shown the amount of words that are created but *_not_* the words themselves.\n\n8. Use `/angrms challenge @someone` to play head-to-head.  Only the two of you can see the game, every word goes to whoever finds it first, and the winner is announced when time runs out or someone finds every word.\n\n9. Use `/angrms team` in a channel to start a game everyone there solves together.  Every word anyone finds counts for the whole channel, a pinned message with a *Play* button keeps track of who found what, and the channel celebrates when the last word is found.\n\n10. Pick a sprint length to give every player one timed attempt.  Their clock starts when they open the game, and players are ranked by how many words they found before time ran out."
 	createBlock := slack.NewTextBlockObject("mrkdwn", createMessage, false, false)
 	createSection := slack.NewSectionBlock(createBlock, nil, nil)
 
 	playHeader := slack.NewHeaderBlock(slack.NewTextBlockObject("plain_text", "How to play", false, false))
-	playMessage := "1. You will guess one word at a time\n\n2. All games created will potentially have any of the letters used multiple times in the words.\n\n3. If a word is correct it will show up at the bottom\n\n4. If a guess is incorrect, nothing will happen and you need to manually clear the guess\n\n5. When you find all the words in a game you will be added to that game's leaderboard.\n\n6. Have fun! :confetti_ball:"
-	playBlock := slack.NewTextBlockObject("plain_text", playMessage, false, false)
+	playMessage := "1. You will guess one word at a time\n\n2. All games created will potentially have any of the letters used multiple times in the words, except games marked *anagram mode* in the game list.  Those only let you use each letter as many times as it is shown.\n\n3. If a word is correct it will show up at the bottom.  Pick it from *Define a word* to see what it means\n\n4. If a guess is incorrect, nothing will happen and you need to manually clear the guess\n\n5. Giving up?  *Reveal answers* shows every word, but you can't keep playing that game or get on its leaderboard afterwards.  Once a game ends, opening it shows the same list.\n\n6. Your progress is saved as you go.  Close a game any time and pick it up again from the *Continue* section of the `/angrms` menu.\n\n7. Every word scores points: 1 for short words and a point per letter for words of 4 letters or more.  In anagram games and games with a required letter, words that use all of the game's letters get a 7 point bonus, and finding a word within 30 seconds of your last one is worth 2 more.\n\n8. Stuck?  The *Hint* button shows the length and first letter of a word you haven't found, and another letter each time you press it.  Every hint costs 3 points and solving with hints is noted on the leaderboard.\n\n9. Sprint games start a clock as soon as you open them.  You only get one attempt, and guesses after the deadline don't count.\n\n10. When you find all the words in a game you will be added to that game's leaderboard.\n\n11. Have fun! :confetti_ball:"
+	playBlock := slack.NewTextBlockObject("mrkdwn", playMessage, false, false)
 	playSection := slack.NewSectionBlock(playBlock, nil, nil)
 
 	view.Blocks.BlockSet = []slack.Block{
@@ -661,9 +923,9 @@ func Instructions(triggerID string, res http.ResponseWriter, push bool) {
 	)
 
 	if push {
-		apiRes, err = api.PushView(triggerID, view)
+		apiRes, err = app.api.PushView(triggerID, view)
 	} else {
-		apiRes, err = api.OpenView(triggerID, view)
+		apiRes, err = app.api.OpenView(triggerID, view)
 	}
 
 	if err != nil {
@@ -671,8 +933,17 @@ func Instructions(triggerID string, res http.ResponseWriter, push bool) {
 	}
 }
 
-func mainMenu(res http.ResponseWriter, command slack.SlashCommand) {
-	firstname, _, _ := getUser(command.UserName)
+func (app *App) mainMenu(res http.ResponseWriter, command slack.SlashCommand) {
+	apiRes, err := app.api.OpenView(command.TriggerID, app.mainMenuView(command.UserName))
+
+	if err != nil {
+		fmt.Printf("%+v", apiRes)
+		return
+	}
+}
+
+func (app *App) mainMenuView(user string) slack.ModalViewRequest {
+	firstname, _, _ := getUser(user)
 	var view slack.ModalViewRequest
 	view.Type = slack.ViewType("modal")
 	view.CallbackID = "main"
@@ -731,17 +1002,19 @@ func mainMenu(res http.ResponseWriter, command slack.SlashCommand) {
 		statsSection,
 	}
 
-	apiRes, err := api.OpenView(command.TriggerID, view)
+	view.Blocks.BlockSet = append(view.Blocks.BlockSet, app.continueSection(user)...)
 
-	if err != nil {
-		fmt.Printf("%+v", apiRes)
-		return
-	}
+	return view
 }
 
-func ParseMenu(req slack.InteractionCallback, res http.ResponseWriter) {
+func (app *App) ParseMenu(req slack.InteractionCallback, res http.ResponseWriter) {
 	selectedOption := req.ActionCallback.BlockActions[0].ActionID
 
+	if strings.HasPrefix(selectedOption, "continue-") {
+		app.StartGame(req, res)
+		return
+	}
+
 	var view slack.ModalViewRequest
 	switch selectedOption {
 	case "create":
@@ -753,16 +1026,18 @@ func ParseMenu(req slack.InteractionCallback, res http.ResponseWriter) {
 			private = true
 		}
 
-		offsetMeta := req.View.PrivateMetadata
-
-		offset, err := strconv.Atoi()
+		var meta Metadata
+		if req.View.PrivateMetadata != "" {
+			err := json.Unmarshal([]byte(req.View.PrivateMetadata), &meta)
 
-		if err != nil {
-			fmt.Printf("%+v", err)
-			res.WriteHeader(http.StatusInternalServerError)
-			return
+			if err != nil {
+				fmt.Printf("%+v", err)
+				res.WriteHeader(http.StatusInternalServerError)
+				return
+			}
 		}
-		modal, games := findGameModal(res, req.User.Name, private, offset)
+
+		modal, games := app.findGameModal(res, req.User.Name, private, meta.Offset)
 		view = modal
 
 		if len(games) == 0 {
@@ -773,14 +1048,14 @@ func ParseMenu(req slack.InteractionCallback, res http.ResponseWriter) {
 			}
 		}
 	case "stats":
-		StatsInitView(res, req.TriggerID, true)
+		app.StatsInitView(res, req.TriggerID, onModal(req))
 		return
 	case "tips":
-		Instructions(req.TriggerID, res, true)
+		app.Instructions(req.TriggerID, res, onModal(req))
 		return
 	}
 
-	apiRes, err := api.PushView(req.TriggerID, view)
+	apiRes, err := app.showView(req, view)
 
 	if err != nil {
 		fmt.Printf("%+v", apiRes)
@@ -788,8 +1063,8 @@ func ParseMenu(req slack.InteractionCallback, res http.ResponseWriter) {
 	}
 }
 
-func StatsInitView(res http.ResponseWriter, triggerID string, push bool) {
-	games := addGameOptions(0, getGames(res, bson.E{}))
+func (app *App) StatsInitView(res http.ResponseWriter, triggerID string, push bool) {
+	games := addGameOptions(0, app.getGames(GameQuery{}))
 	var view slack.ModalViewRequest
 	view.Type = slack.ViewType("modal")
 	view.CallbackID = "gamestats"
@@ -801,10 +1076,17 @@ func StatsInitView(res http.ResponseWriter, triggerID string, push bool) {
 	headerBlock := slack.NewTextBlockObject("plain_text", header, false, false)
 	headerSection := slack.NewSectionBlock(headerBlock, nil, nil)
 
+	monthlyMessage := slack.NewTextBlockObject("plain_text", "See who's leading this month", false, false)
+	monthlyButtonText := slack.NewTextBlockObject("plain_text", "Monthly leaders", false, false)
+	monthlyButton := slack.NewButtonBlockElement("monthly", "monthly", monthlyButtonText)
+	monthlySection := slack.NewSectionBlock(monthlyMessage, nil, slack.NewAccessory(monthlyButton))
+	monthlySection.BlockID = "monthly"
+
 	view.Blocks = slack.Blocks{
 		BlockSet: []slack.Block{
-			headerSection,
+			monthlySection,
 			slack.NewDividerBlock(),
+			headerSection,
 			games,
 		},
 	}
@@ -812,9 +1094,9 @@ func StatsInitView(res http.ResponseWriter, triggerID string, push bool) {
 	var err error
 	var apiRes *slack.ViewResponse
 	if push {
-		apiRes, err = api.PushView(triggerID, view)
+		apiRes, err = app.api.PushView(triggerID, view)
 	} else {
-		apiRes, err = api.OpenView(triggerID, view)
+		apiRes, err = app.api.OpenView(triggerID, view)
 	}
 
 	if err != nil {
@@ -823,10 +1105,14 @@ func StatsInitView(res http.ResponseWriter, triggerID string, push bool) {
 	}
 }
 
-func ShowStats(req slack.InteractionCallback, res http.ResponseWriter) {
+func (app *App) ShowStats(req slack.InteractionCallback, res http.ResponseWriter) {
+	if req.ActionCallback.BlockActions[0].ActionID == "monthly" {
+		app.monthlyLeaders(req, res)
+		return
+	}
+
 	gameID, _ := primitive.ObjectIDFromHex(req.ActionCallback.BlockActions[0].SelectedOption.Value)
-	var game Game
-	client.Collection("games").FindOne(context.TODO(), bson.E{"_id", gameID}).Decode(&game)
+	game, _ := app.store.GetGame(gameID)
 	solvedLayout := "_2 Jan 2006 3:04:05 PM"
 	layout := "_2 Jan 2006 3:04 PM"
 
@@ -841,10 +1127,39 @@ func ShowStats(req slack.InteractionCallback, res http.ResponseWriter) {
 
 	var board []slack.Block
 	board = append(board, headerSection)
+
+	if len(game.Scores) > 0 {
+		scoresHeader := slack.NewHeaderBlock(slack.NewTextBlockObject("plain_text", "Scores", false, false))
+		board = append(board, scoresHeader)
+	}
+
+	scores := rankedScores(game.Scores)
+	if game.Sprint > 0 {
+		scores = rankedSprintScores(game.Scores)
+	}
+
+	for i, score := range scores {
+		position := strconv.Itoa(i + 1)
+		_, _, user := getUser(score.User)
+		words := strconv.Itoa(score.Words) + "/" + strconv.Itoa(len(game.Words)) + " words"
+
+		row := slack.NewTextBlockObject("mrkdwn", "*"+position+")*  _"+user+"_ - *"+strconv.Itoa(score.Points)+"* points, "+words, false, false)
+		rowSection := slack.NewSectionBlock(row, nil, nil)
+		board = append(board, rowSection)
+	}
+
+	if len(game.Leaderboard) > 0 {
+		solvedHeader := slack.NewHeaderBlock(slack.NewTextBlockObject("plain_text", "Solved", false, false))
+		board = append(board, solvedHeader)
+	}
+
 	for i, solved := range game.Leaderboard {
 		position := strconv.Itoa(i + 1)
 		_, _, user := getUser(solved.User)
 		date := solved.Date.Local().Format(solvedLayout)
+		if solved.Assisted {
+			date += " (with hints)"
+		}
 
 		row := slack.NewTextBlockObject("mrkdwn", "*"+position+")*  _"+user+"_ - "+date, false, false)
 		rowSection := slack.NewSectionBlock(row, nil, nil)
@@ -853,7 +1168,7 @@ func ShowStats(req slack.InteractionCallback, res http.ResponseWriter) {
 
 	view.Blocks.BlockSet = board
 
-	apiRes, err := api.UpdateView(view, "", req.Hash, req.View.ID)
+	apiRes, err := app.api.UpdateView(view, "", req.Hash, req.View.ID)
 
 	if err != nil {
 		fmt.Printf("%+v", apiRes)
@@ -0,0 +1,249 @@
+package args
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/util"
+)
+
+var challengeExpiration = os.Getenv("CHALLENGE_EXPIRATION")
+
+// Slack sends mentions as <@U123|name> or <@U123> when escaping is on, and
+// as plain @name when it is off.
+var escapedMention = regexp.MustCompile(`^<@([A-Z0-9]+)(?:\|([^>]+))?>$`)
+
+// Challenge is a game between two players. Each word counts for whoever
+// found it first.
+type Challenge struct {
+	Players   []string    `bson:"players"`
+	PlayerIDs []string    `bson:"playerIds"`
+	Firsts    []FirstFind `bson:"firsts"`
+	Winner    string      `bson:"winner,omitempty"`
+}
+
+type FirstFind struct {
+	Word string    `bson:"word"`
+	User string    `bson:"user"`
+	Date time.Time `bson:"date"`
+}
+
+func (game Game) canPlay(user string) bool {
+	if game.Challenge == nil {
+		return true
+	}
+
+	for _, player := range game.Challenge.Players {
+		if player == user {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveMention turns the mention typed after /angrms challenge into the
+// user's ID and name.
+func (app *App) resolveMention(mention string) (string, string, error) {
+	if match := escapedMention.FindStringSubmatch(mention); match != nil {
+		if match[2] != "" {
+			return match[1], match[2], nil
+		}
+
+		user, err := app.api.GetUserInfo(match[1])
+
+		if err != nil {
+			return "", "", err
+		}
+
+		return user.ID, user.Name, nil
+	}
+
+	name := strings.TrimPrefix(mention, "@")
+	users, err := app.api.GetUsers()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, user := range users {
+		if user.Name == name {
+			return user.ID, user.Name, nil
+		}
+	}
+
+	return "", "", errors.New("no user named " + name)
+}
+
+func (app *App) createChallenge(res http.ResponseWriter, command slack.SlashCommand, params []string) {
+	if len(params) == 0 {
+		res.Write([]byte("Who do you want to challenge?  Try `/angrms challenge @someone [letters]`"))
+		return
+	}
+
+	opponentID, opponent, err := app.resolveMention(params[0])
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		res.Write([]byte("Couldn't find " + params[0] + " :cry:"))
+		return
+	}
+
+	if opponent == command.UserName {
+		res.Write([]byte("You can't challenge yourself!"))
+		return
+	}
+
+	expiration := challengeExpiration
+	if expiration == "" {
+		expiration = "1d"
+	}
+
+	options := commandOptions(params[1:])
+	options.Expiration = expiration
+	game, err := newGame(command.TeamID, options)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		res.Write([]byte(createErrorText(game, options, err)))
+		return
+	}
+
+	game.User = command.UserName
+	game.Private = true
+	game.Challenge = &Challenge{
+		Players:   []string{command.UserName, opponent},
+		PlayerIDs: []string{command.UserID, opponentID},
+		Firsts:    make([]FirstFind, 0),
+	}
+
+	if err := app.insertGame(game); err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _, challenger := getUser(command.UserName)
+	message := ":crossed_swords: *" + challenger + "* started an Angrms challenge with <@" + opponentID + ">!\n*" + strings.ToUpper(game.Letters) + "* - " + strconv.Itoa(len(game.Words)) + " words.  Every word counts for whoever finds it first, and the challenge ends " + expiryText(game.ExpiresAt) + " or when someone finds them all.\nUse `/angrms find private` to play."
+	app.notifyPlayers(game.Challenge, message)
+
+	res.Write([]byte("Challenge sent to <@" + opponentID + ">! :crossed_swords:"))
+}
+
+func (app *App) notifyPlayers(challenge *Challenge, message string) {
+	for _, id := range challenge.PlayerIDs {
+		_, _, err := app.api.PostMessage(id, slack.MsgOptionText(message, false))
+
+		if err != nil {
+			fmt.Printf("%+v", err)
+		}
+	}
+}
+
+// claimFirst records user as the first to find word, unless the other
+// player already has.
+func (app *App) claimFirst(game Game, user string, found engine.FoundWord) error {
+	_, err := app.store.ClaimFirst(game.Id, FirstFind{Word: found.Word, User: user, Date: found.Date})
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	return err
+}
+
+func (challenge *Challenge) tally() []util.GamesStats {
+	var tally []util.GamesStats
+
+	for _, player := range challenge.Players {
+		count := 0
+		for _, first := range challenge.Firsts {
+			if first.User == player {
+				count++
+			}
+		}
+
+		tally = append(tally, util.GamesStats{User: player, Amount: count})
+	}
+
+	return tally
+}
+
+// challengeWinner is whoever found the most words first, or empty for a
+// draw.
+func challengeWinner(tally []util.GamesStats) string {
+	if len(tally) != 2 || tally[0].Amount == tally[1].Amount {
+		return ""
+	}
+
+	if tally[1].Amount > tally[0].Amount {
+		return tally[1].User
+	}
+
+	return tally[0].User
+}
+
+// finishChallenge closes a challenge game, once every word is found or time
+// runs out, and tells both players who won.
+func (app *App) finishChallenge(game Game) {
+	// Reload to pick up words the other player claimed since game was read.
+	game = app.getGame(game.Id)
+
+	if game.Challenge == nil {
+		return
+	}
+
+	now := time.Now()
+	tally := game.Challenge.tally()
+	winner := challengeWinner(tally)
+
+	closed, err := app.store.CloseChallenge(game.Id, now, winner)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		return
+	}
+
+	// Someone else already closed it.
+	if !closed {
+		return
+	}
+
+	var scores []string
+	for _, first := range tally {
+		_, _, name := getUser(first.User)
+		scores = append(scores, name+": "+strconv.Itoa(first.Amount))
+	}
+
+	message := ":checkered_flag: The *" + strings.ToUpper(game.Letters) + "* challenge is over. "
+	if winner == "" {
+		message += "It's a draw!"
+	} else {
+		_, _, name := getUser(winner)
+		message += "*" + name + "* wins! :trophy:"
+	}
+
+	message += "\nWords found first - " + strings.Join(scores, ", ")
+	app.notifyPlayers(game.Challenge, message)
+}
+
+func (app *App) finishExpiredChallenges(now time.Time) error {
+	games, err := app.store.FindGames(GameQuery{Active: true, Challenge: true, ExpiredBy: now})
+
+	if err != nil {
+		return err
+	}
+
+	for _, game := range games {
+		app.finishChallenge(game)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package args
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+)
+
+var dailyChannel = os.Getenv("DAILY_CHANNEL")
+var dailyTime = os.Getenv("DAILY_TIME")
+var dailyLetters = envInt("DAILY_LETTERS", defaultRandomLetters)
+
+// dailyUser is who daily puzzles are created by. It has to go through
+// getUser like any other creator, so it needs the name separator.
+var dailyUser = "daily" + nameSeparator + "puzzle"
+
+// nextDailyRun returns the first time after now that the clock reads
+// hour:minute in now's location.
+func nextDailyRun(now time.Time, hour int, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next
+}
+
+// RunDailyScheduler posts a new daily puzzle to DAILY_CHANNEL every day at
+// DAILY_TIME, in the workspace's TIMEZONE, and closes the previous one. It
+// blocks, so run it in its own goroutine. Nothing is scheduled when
+// DAILY_CHANNEL isn't set.
+func (app *App) RunDailyScheduler() {
+	if dailyChannel == "" {
+		return
+	}
+
+	at := dailyTime
+
+	if at == "" {
+		at = "09:00"
+	}
+
+	clock, err := time.Parse("15:04", at)
+
+	if err != nil {
+		fmt.Printf("DAILY_TIME should look like 09:00: %+v", err)
+		return
+	}
+
+	location := workspaceLocation
+
+	for {
+		next := nextDailyRun(time.Now().In(location), clock.Hour(), clock.Minute())
+		time.Sleep(time.Until(next))
+
+		if err := app.postDailyPuzzle(); err != nil {
+			fmt.Printf("%+v", err)
+		}
+	}
+}
+
+// postDailyPuzzle saves today's puzzle before closing the old ones, so
+// there's still a daily game to play if making the new one fails.
+func (app *App) postDailyPuzzle() error {
+	game, err := newGame("", engine.CreateOptions{Random: dailyLetters, Mode: engine.ModeReuse})
+
+	if err != nil {
+		return err
+	}
+
+	game.User = dailyUser
+	game.Daily = true
+
+	if err := app.insertGame(game); err != nil {
+		return err
+	}
+
+	if err := app.store.CloseDailyGames(game.Id, time.Now()); err != nil {
+		return err
+	}
+
+	date := time.Now().In(workspaceLocation).Format("Monday, Jan 2")
+	message := ":sunrise: *Angrms daily puzzle for " + date + "*\n*" + strings.ToUpper(game.Letters) + "* - " + strconv.Itoa(len(game.Words)) + " words to find.  Press *Play* or use `/angrms find` to play!"
+	messageBlock := slack.NewTextBlockObject("mrkdwn", message, false, false)
+	messageSection := slack.NewSectionBlock(messageBlock, nil, nil)
+	playSection := slack.NewActionBlock("play-"+game.Id.Hex(), playButton(game))
+
+	_, _, err = app.api.PostMessage(dailyChannel, slack.MsgOptionBlocks(messageSection, playSection), slack.MsgOptionText(message, false))
+	return err
+}
+
+// dailyFirst puts the daily games ahead of the rest without listing any
+// twice.
+func dailyFirst(daily []Game, games []Game) []Game {
+	listed := append([]Game(nil), daily...)
+
+	for _, game := range games {
+		if !game.Daily {
+			listed = append(listed, game)
+		}
+	}
+
+	return listed
+}
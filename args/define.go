@@ -0,0 +1,70 @@
+package args
+
+import (
+	"fmt"
+
+	"github.com/slack-go/slack"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/slices"
+)
+
+// maxSelectOptions is the most options Slack allows in a select menu.
+const maxSelectOptions = 100
+
+// defineSelect lets the player look up the words they found that the
+// lexicon has a definition for, most recent first. It returns nil if there
+// are none.
+func defineSelect(words []string) *slack.ActionBlock {
+	var options []*slack.OptionBlockObject
+	for i := len(words) - 1; i >= 0 && len(options) < maxSelectOptions; i-- {
+		if _, ok := slices.Define(words[i]); !ok {
+			continue
+		}
+
+		text := slack.NewTextBlockObject("plain_text", words[i], false, false)
+		options = append(options, slack.NewOptionBlockObject(words[i], text, nil))
+	}
+
+	if len(options) == 0 {
+		return nil
+	}
+
+	placeholder := slack.NewTextBlockObject("plain_text", "Define a word", false, false)
+	return slack.NewActionBlock("define", slack.NewOptionsSelectBlockElement("static_select", placeholder, "define", options...))
+}
+
+// showDefinition shows the word's definition from the offline lexicon in the
+// play modal, under the define menu, replacing any shown before. It updates
+// the modal in place since it may already be the third view in the stack.
+func (app *App) showDefinition(req slack.InteractionCallback, word string) {
+	definition, ok := slices.Define(word)
+
+	if !ok {
+		definition = "No definition available for this one yet."
+	}
+
+	message := "*" + word + "*\n" + definition
+	messageBlock := slack.NewTextBlockObject("mrkdwn", message, false, false)
+	definitionSection := slack.NewSectionBlock(messageBlock, nil, nil, slack.SectionBlockOptionBlockID("definition"))
+
+	view := updateModal(req)
+	view.CallbackID = "play"
+	view.PrivateMetadata = req.View.PrivateMetadata
+
+	for _, block := range req.View.Blocks.BlockSet {
+		if section, ok := block.(*slack.SectionBlock); ok && section.BlockID == "definition" {
+			continue
+		}
+
+		view.Blocks.BlockSet = append(view.Blocks.BlockSet, block)
+
+		if actions, ok := block.(*slack.ActionBlock); ok && actions.BlockID == "define" {
+			view.Blocks.BlockSet = append(view.Blocks.BlockSet, definitionSection)
+		}
+	}
+
+	apiRes, err := app.api.UpdateView(view, "", req.Hash, req.View.ID)
+
+	if err != nil {
+		fmt.Printf("%+v", apiRes)
+	}
+}
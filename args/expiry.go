@@ -0,0 +1,160 @@
+package args
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/slack-go/slack"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+)
+
+const sweepInterval = time.Minute
+
+var workspaceLocation = loadLocation(os.Getenv("TIMEZONE"))
+
+func loadLocation(name string) *time.Location {
+	if name == "" {
+		return time.Local
+	}
+
+	location, err := time.LoadLocation(name)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		return time.Local
+	}
+
+	return location
+}
+
+// parseExpiry reads the expiration typed into the create modal in the
+// workspace's timezone.
+func parseExpiry(chosenExpiry string, creation time.Time) (time.Time, error) {
+	return engine.ParseExpiry(chosenExpiry, creation.In(workspaceLocation))
+}
+
+func expiryText(expiresAt time.Time) string {
+	return expiresAt.In(workspaceLocation).Format("Monday, Jan 2 at 3:04 PM MST")
+}
+
+// Puzzle is the part of a game the engine plays by.
+func (game Game) Puzzle() engine.Game {
+	return engine.Game{
+		Letters:   game.Letters,
+		Words:     game.Words,
+		Mode:      game.Mode,
+		Rules:     game.Rules,
+		Active:    game.Active,
+		ExpiresAt: game.ExpiresAt,
+	}
+}
+
+func (game Game) expired(now time.Time) bool {
+	return game.Puzzle().Expired(now)
+}
+
+func (game Game) playable(now time.Time) bool {
+	return game.Puzzle().Playable(now)
+}
+
+func gameOverMessage(game Game) string {
+	if game.Shared != nil && len(game.Shared.Found) == len(game.Words) {
+		return "Your channel found every word in this game! :tada:"
+	}
+
+	if !game.ExpiresAt.IsZero() && game.expired(time.Now()) {
+		return "This game expired on " + expiryText(game.ExpiresAt) + " and can't be played anymore :hourglass:"
+	}
+
+	return "This game has ended and can't be played anymore :hourglass:"
+}
+
+// gameOver replaces the play modal with the end of game summary.
+func (app *App) gameOver(req slack.InteractionCallback, res http.ResponseWriter, game Game) {
+	found := app.playerProgress(game, req.User.Name).found()
+	view := summaryView(game, found, "Game over", gameOverMessage(game))
+
+	apiRes, err := app.api.UpdateView(view, "", req.Hash, req.View.ID)
+
+	if err != nil {
+		fmt.Printf("%+v", apiRes)
+	}
+}
+
+func (app *App) closeExpiredGames(now time.Time) error {
+	expiring, err := app.store.FindGames(GameQuery{Active: true, ExpiredBy: now})
+
+	if err != nil {
+		return err
+	}
+
+	var closed []Game
+	for _, game := range expiring {
+		result := engine.Expire(game.Puzzle(), now)
+
+		if !result.Expired {
+			continue
+		}
+
+		updated, err := app.store.CloseGame(game.Id, result.ClosedAt)
+
+		if err != nil {
+			return err
+		}
+
+		if updated {
+			closed = append(closed, game)
+		}
+	}
+
+	app.announceExpiredGames(closed)
+	return nil
+}
+
+// backfillExpiry works out the end time of active games saved with only
+// the expiration they were created with.
+func (app *App) backfillExpiry() error {
+	games, err := app.store.FindGames(GameQuery{Active: true, MissingExpiry: true})
+
+	if err != nil {
+		return err
+	}
+
+	for _, game := range games {
+		expiresAt, err := parseExpiry(game.Expiration, game.Date)
+
+		if err != nil {
+			fmt.Printf("game %s: can't read expiration %q: %+v\n", game.Id.Hex(), game.Expiration, err)
+			continue
+		}
+
+		if err := app.store.SetExpiry(game.Id, expiresAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunExpirySweeper deactivates games once their expiration has passed. It
+// blocks, so run it in its own goroutine.
+func (app *App) RunExpirySweeper() {
+	if err := app.backfillExpiry(); err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		if err := app.finishExpiredChallenges(now); err != nil {
+			fmt.Printf("%+v", err)
+		}
+
+		if err := app.closeExpiredGames(now); err != nil {
+			fmt.Printf("%+v", err)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package args
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Hint is the word a player is currently being helped with and how many of
+// its letters they have been shown.
+type Hint struct {
+	Word     string `bson:"word"`
+	Revealed int    `bson:"revealed"`
+}
+
+func hintButton() *slack.Accessory {
+	hintText := slack.NewTextBlockObject("plain_text", "Hint (-"+strconv.Itoa(engine.HintCost)+" points)", false, false)
+	return slack.NewAccessory(slack.NewButtonBlockElement("hint", "hint", hintText))
+}
+
+// hintText describes the current hint, or returns an empty string if the
+// player has found the word or never asked for one.
+func hintText(progress Progress) string {
+	hint := progress.Hint
+
+	if hint == nil || alreadyGuessed(progress.found(), hint.Word) {
+		return ""
+	}
+
+	return "Hint: " + strconv.Itoa(len(hint.Word)) + " letters, starting with *" + strings.ToUpper(hint.Word[:hint.Revealed]) + "*"
+}
+
+// nextHint reveals one more letter of the current hint word. Once that word
+// is found, or all but its last letter are showing, a new unfound word is
+// picked at random.
+func nextHint(game Game, progress Progress) (Hint, bool) {
+	found := progress.found()
+
+	if hint := progress.Hint; hint != nil && !alreadyGuessed(found, hint.Word) && hint.Revealed < len(hint.Word)-1 {
+		return Hint{Word: hint.Word, Revealed: hint.Revealed + 1}, true
+	}
+
+	var unfound []string
+	for _, word := range game.Words {
+		if !alreadyGuessed(found, word) {
+			unfound = append(unfound, word)
+		}
+	}
+
+	if len(unfound) == 0 {
+		return Hint{}, false
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return Hint{Word: unfound[rng.Intn(len(unfound))], Revealed: 1}, true
+}
+
+func (app *App) giveHint(req slack.InteractionCallback, res http.ResponseWriter) {
+	gameHex := req.View.PrivateMetadata
+	gameId, err := primitive.ObjectIDFromHex(gameHex)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	game := app.getGame(gameId)
+
+	if !game.playable(time.Now()) {
+		app.gameOver(req, res, game)
+		return
+	}
+
+	user := req.User.Name
+	progress := app.playerProgress(game, user)
+
+	if progress.sprintOver(time.Now()) {
+		app.timeUp(req, res, game, progress.found())
+		return
+	}
+
+	hint, ok := nextHint(game, progress)
+
+	if !ok {
+		return
+	}
+
+	progress.Hint = &hint
+	progress.HintsUsed++
+
+	if err := app.store.UseHint(gameId, user, hint, time.Now()); err != nil {
+		fmt.Printf("%+v", err)
+	}
+	app.recordScore(game, user, progress)
+
+	view := updateModal(req)
+	view.CallbackID = "play"
+	view.PrivateMetadata = gameHex
+	view.Blocks = req.View.Blocks
+	view.Blocks.BlockSet[1] = wordsLeftSection(game, progress)
+
+	apiRes, err := app.api.UpdateView(view, "", req.Hash, req.View.ID)
+
+	if err != nil {
+		fmt.Printf("%+v", apiRes)
+	}
+}
@@ -0,0 +1,176 @@
+package args
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const homeListLimit = 5
+
+func (app *App) findGames(query GameQuery) []Game {
+	games, err := app.store.FindGames(query)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	return games
+}
+
+// playableGames are the newest games the user can play and hasn't solved.
+func (app *App) playableGames(user string) []Game {
+	query := GameQuery{
+		Active:     true,
+		VisibleTo:  user,
+		UnsolvedBy: user,
+		Solo:       true,
+		Sort:       SortNewest,
+		Limit:      homeListLimit * 2,
+	}
+
+	var playable []Game
+	now := time.Now()
+	for _, game := range app.findGames(query) {
+		if game.playable(now) && game.canPlay(user) && len(playable) < homeListLimit {
+			playable = append(playable, game)
+		}
+	}
+
+	return playable
+}
+
+func (app *App) recentlySolved(user string) []Game {
+	return app.findGames(GameQuery{SolvedBy: user, Sort: SortRecentlySolved, Limit: homeListLimit})
+}
+
+func (app *App) createdGames(user string) []Game {
+	return app.findGames(GameQuery{Creator: user, Sort: SortNewest, Limit: homeListLimit})
+}
+
+func homeHeader(text string) []slack.Block {
+	return []slack.Block{
+		slack.NewDividerBlock(),
+		slack.NewHeaderBlock(slack.NewTextBlockObject("plain_text", text, false, false)),
+	}
+}
+
+func homeEmpty(text string) slack.Block {
+	return slack.NewContextBlock("", slack.NewTextBlockObject("mrkdwn", text, false, false))
+}
+
+func quickActions() slack.Block {
+	button := func(actionID string, text string) slack.BlockElement {
+		return slack.NewButtonBlockElement(actionID, actionID, slack.NewTextBlockObject("plain_text", text, false, false))
+	}
+
+	create := slack.NewButtonBlockElement("create", "create", slack.NewTextBlockObject("plain_text", "Create a game", false, false))
+	create.Style = slack.StylePrimary
+
+	return slack.NewActionBlock("home-actions",
+		create,
+		button("play", "Find a game"),
+		button("play-private", "Private games"),
+		button("stats", "Stats"),
+		button("tips", "How to play"),
+		button("menu", "Main menu"),
+	)
+}
+
+// homeView is the user's Angrms dashboard on the App Home tab.
+func (app *App) homeView(user string) slack.HomeTabViewRequest {
+	firstname, _, _ := getUser(user)
+
+	welcome := slack.NewTextBlockObject("mrkdwn", ":wave: Hey *"+firstname+"*, here's what's going on in Angrms.", false, false)
+	blocks := []slack.Block{
+		slack.NewSectionBlock(welcome, nil, nil),
+		quickActions(),
+	}
+
+	blocks = append(blocks, homeHeader("Games to play")...)
+	playable := app.playableGames(user)
+	for _, game := range playable {
+		_, _, creator := getUser(game.User)
+		text := "*" + strings.ToUpper(game.Letters) + "* - " + creator + "\n" + strconv.Itoa(len(game.Words)) + " words"
+		if !game.ExpiresAt.IsZero() {
+			text += ", playable until " + expiryText(game.ExpiresAt)
+		}
+
+		textBlock := slack.NewTextBlockObject("mrkdwn", text, false, false)
+		blocks = append(blocks, slack.NewSectionBlock(textBlock, nil, slack.NewAccessory(playButton(game))))
+	}
+
+	if len(playable) == 0 {
+		blocks = append(blocks, homeEmpty("No games waiting for you.  Why not create one?"))
+	}
+
+	// continueSection brings its own divider and header.
+	if inProgress := app.continueSection(user); len(inProgress) > 0 {
+		blocks = append(blocks, inProgress...)
+	}
+
+	blocks = append(blocks, homeHeader("Recently solved")...)
+	solved := app.recentlySolved(user)
+	for _, game := range solved {
+		_, _, creator := getUser(game.User)
+		text := ":white_check_mark: *" + strings.ToUpper(game.Letters) + "* - " + creator
+		for _, board := range game.Leaderboard {
+			if board.User == user {
+				text += ", " + board.Date.In(workspaceLocation).Format("Jan 2")
+				break
+			}
+		}
+
+		blocks = append(blocks, slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil))
+	}
+
+	if len(solved) == 0 {
+		blocks = append(blocks, homeEmpty("You haven't solved any games yet."))
+	}
+
+	blocks = append(blocks, homeHeader("Games you created")...)
+	created := app.createdGames(user)
+	now := time.Now()
+	for _, game := range created {
+		status := "open"
+		if !game.playable(now) {
+			status = "closed"
+		}
+
+		solvers := strconv.Itoa(len(game.Leaderboard)) + " solved"
+		text := "*" + strings.ToUpper(game.Letters) + "* - " + game.Date.In(workspaceLocation).Format("Jan 2") + ", " + status + "\n" + solvers + ", " + strconv.Itoa(len(game.Scores)) + " played"
+
+		blocks = append(blocks, slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil))
+	}
+
+	if len(created) == 0 {
+		blocks = append(blocks, homeEmpty("You haven't created any games yet."))
+	}
+
+	var view slack.HomeTabViewRequest
+	view.Type = slack.VTHomeTab
+	view.CallbackID = "home"
+	view.Blocks.BlockSet = blocks
+
+	return view
+}
+
+// PublishHome refreshes the App Home tab for the user with the given ID.
+func (app *App) PublishHome(userID string) {
+	user, err := app.api.GetUserInfo(userID)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		return
+	}
+
+	apiRes, err := app.api.PublishView(userID, app.homeView(user.Name), "")
+
+	if err != nil {
+		fmt.Printf("%+v", apiRes)
+		fmt.Printf("%+v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package args
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/slack-go/slack"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/util"
+)
+
+const rollupInterval = time.Hour
+
+func (app *App) rollupLeaders(now time.Time) {
+	months := []time.Time{now}
+
+	// Solves late on the last day of a month are only picked up by the
+	// first rollup of the next one.
+	if now.Day() == 1 {
+		months = append(months, now.AddDate(0, 0, -1))
+	}
+
+	for _, month := range months {
+		if err := app.store.RollupLeaders(month); err != nil {
+			fmt.Printf("%+v", err)
+		}
+	}
+}
+
+// RunLeadersRollup keeps the monthly leaders collection up to date. It
+// blocks, so run it in its own goroutine.
+func (app *App) RunLeadersRollup() {
+	app.rollupLeaders(time.Now())
+
+	ticker := time.NewTicker(rollupInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		app.rollupLeaders(now)
+	}
+}
+
+func leaderRows(title string, unit string, leaders []util.GamesStats) []slack.Block {
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject("plain_text", title, false, false)),
+	}
+
+	if len(leaders) == 0 {
+		empty := slack.NewTextBlockObject("plain_text", "Nobody yet this month", false, false)
+		return append(blocks, slack.NewSectionBlock(empty, nil, nil))
+	}
+
+	for i, leader := range leaders {
+		position := strconv.Itoa(i + 1)
+		_, _, user := getUser(leader.User)
+
+		row := slack.NewTextBlockObject("mrkdwn", "*"+position+")*  _"+user+"_ - "+strconv.Itoa(leader.Amount)+" "+unit, false, false)
+		blocks = append(blocks, slack.NewSectionBlock(row, nil, nil))
+	}
+
+	return blocks
+}
+
+func (app *App) monthlyLeaders(req slack.InteractionCallback, res http.ResponseWriter) {
+	now := time.Now()
+
+	var view slack.ModalViewRequest
+	view.Type = slack.ViewType("modal")
+	view.Title = slack.NewTextBlockObject("plain_text", "Monthly leaders", false, false)
+	view.Close = slack.NewTextBlockObject("plain_text", "Back", false, false)
+
+	header := "Leaders for " + now.Format("January 2006")
+	headerBlock := slack.NewTextBlockObject("mrkdwn", "*"+header+"*", false, false)
+	view.Blocks.BlockSet = []slack.Block{
+		slack.NewSectionBlock(headerBlock, nil, nil),
+	}
+
+	lists := []struct {
+		key   string
+		title string
+		unit  string
+	}{
+		{"solved", "Most games solved", "solved"},
+		{"created", "Most games created", "created"},
+		{"usersSolved", "Most popular creators", "solvers"},
+	}
+
+	for _, list := range lists {
+		leaders, err := app.store.AggregateLeaders(now, list.key, 5)
+
+		if err != nil {
+			fmt.Printf("%+v", err)
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		view.Blocks.BlockSet = append(view.Blocks.BlockSet, slack.NewDividerBlock())
+		view.Blocks.BlockSet = append(view.Blocks.BlockSet, leaderRows(list.title, list.unit, leaders)...)
+	}
+
+	apiRes, err := app.api.PushView(req.TriggerID, view)
+
+	if err != nil {
+		fmt.Printf("%+v", apiRes)
+		return
+	}
+}
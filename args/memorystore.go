@@ -0,0 +1,534 @@
+package args
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type progressKey struct {
+	game primitive.ObjectID
+	user string
+}
+
+type memoryStore struct {
+	mu       sync.Mutex
+	order    []primitive.ObjectID
+	games    map[primitive.ObjectID]Game
+	progress map[progressKey]Progress
+	leaders  []util.Leaders
+}
+
+// NewMemoryStore keeps everything in memory, for tests and local
+// development.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		games:    make(map[primitive.ObjectID]Game),
+		progress: make(map[progressKey]Progress),
+	}
+}
+
+// copyGame makes sure callers can't change stored games through shared
+// slices or pointers.
+func copyGame(game Game) Game {
+	game.Words = append([]string(nil), game.Words...)
+	game.Leaderboard = append([]Leaderboard(nil), game.Leaderboard...)
+	game.Scores = append([]Score(nil), game.Scores...)
+
+	if game.Challenge != nil {
+		challenge := *game.Challenge
+		challenge.Players = append([]string(nil), challenge.Players...)
+		challenge.PlayerIDs = append([]string(nil), challenge.PlayerIDs...)
+		challenge.Firsts = append([]FirstFind(nil), challenge.Firsts...)
+		game.Challenge = &challenge
+	}
+
+	if game.Shared != nil {
+		shared := *game.Shared
+		shared.Found = append([]FirstFind(nil), shared.Found...)
+		game.Shared = &shared
+	}
+
+	return game
+}
+
+func copyProgress(progress Progress) Progress {
+	progress.Words = append([]engine.FoundWord(nil), progress.Words...)
+
+	if progress.Hint != nil {
+		hint := *progress.Hint
+		progress.Hint = &hint
+	}
+
+	return progress
+}
+
+func (game Game) isPlayer(user string) bool {
+	return game.User == user || (game.Challenge != nil && game.canPlay(user))
+}
+
+func (query GameQuery) matches(game Game) bool {
+	if len(query.IDs) > 0 {
+		found := false
+		for _, id := range query.IDs {
+			found = found || id == game.Id
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	switch {
+	case query.Active && !game.Active,
+		query.Public && game.Private,
+		query.Creator != "" && game.User != query.Creator,
+		query.Player != "" && !game.isPlayer(query.Player),
+		query.VisibleTo != "" && game.Private && !game.isPlayer(query.VisibleTo),
+		query.SolvedBy != "" && leaderDate(game, query.SolvedBy).IsZero(),
+		query.UnsolvedBy != "" && !leaderDate(game, query.UnsolvedBy).IsZero(),
+		!query.ExpiredBy.IsZero() && (game.ExpiresAt.IsZero() || game.ExpiresAt.After(query.ExpiredBy)),
+		query.MissingExpiry && (game.Expiration == "" || !game.ExpiresAt.IsZero()),
+		query.Challenge && game.Challenge == nil,
+		query.Daily && !game.Daily,
+		query.Solo && game.Shared != nil:
+		return false
+	}
+
+	return true
+}
+
+// leaderDate is when user solved game, or zero if they haven't.
+func leaderDate(game Game, user string) time.Time {
+	for _, leader := range game.Leaderboard {
+		if leader.User == user {
+			return leader.Date
+		}
+	}
+
+	return time.Time{}
+}
+
+func lastSolved(game Game) time.Time {
+	var last time.Time
+	for _, leader := range game.Leaderboard {
+		if leader.Date.After(last) {
+			last = leader.Date
+		}
+	}
+
+	return last
+}
+
+func (store *memoryStore) FindGames(query GameQuery) ([]Game, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var games []Game
+	for _, id := range store.order {
+		if game := store.games[id]; query.matches(game) {
+			games = append(games, copyGame(game))
+		}
+	}
+
+	switch query.Sort {
+	case SortNewest:
+		sort.SliceStable(games, func(i, j int) bool {
+			return games[i].Date.After(games[j].Date)
+		})
+	case SortRecentlySolved:
+		sort.SliceStable(games, func(i, j int) bool {
+			return lastSolved(games[i]).After(lastSolved(games[j]))
+		})
+	}
+
+	if query.Limit > 0 && int64(len(games)) > query.Limit {
+		games = games[:query.Limit]
+	}
+
+	return games, nil
+}
+
+func (store *memoryStore) InsertGame(game Game) (Game, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if game.Id.IsZero() {
+		game.Id = primitive.NewObjectID()
+	}
+
+	store.order = append(store.order, game.Id)
+	store.games[game.Id] = copyGame(game)
+
+	return game, nil
+}
+
+func (store *memoryStore) GetGame(id primitive.ObjectID) (Game, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	game, ok := store.games[id]
+
+	if !ok {
+		return Game{}, ErrNotFound
+	}
+
+	return copyGame(game), nil
+}
+
+// update changes a stored game in place while holding the lock.
+func (store *memoryStore) update(id primitive.ObjectID, change func(game *Game) bool) (bool, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	game, ok := store.games[id]
+
+	if !ok {
+		return false, ErrNotFound
+	}
+
+	game = copyGame(game)
+	changed := change(&game)
+
+	if changed {
+		store.games[id] = game
+	}
+
+	return changed, nil
+}
+
+func (store *memoryStore) AddLeader(id primitive.ObjectID, leader Leaderboard) error {
+	_, err := store.update(id, func(game *Game) bool {
+		for _, existing := range game.Leaderboard {
+			if existing == leader {
+				return false
+			}
+		}
+
+		game.Leaderboard = append(game.Leaderboard, leader)
+		return true
+	})
+
+	return err
+}
+
+func countLeaders(counts map[string]int) []util.GamesStats {
+	var stats []util.GamesStats
+	for user, amount := range counts {
+		stats = append(stats, util.GamesStats{User: user, Amount: amount})
+	}
+
+	sortLeaders(stats)
+	return stats
+}
+
+// sortLeaders puts the highest amounts first. Ties, which the Mongo store
+// leaves in no particular order, are sorted by user.
+func sortLeaders(stats []util.GamesStats) {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Amount != stats[j].Amount {
+			return stats[i].Amount > stats[j].Amount
+		}
+
+		return stats[i].User < stats[j].User
+	})
+}
+
+// AggregateLeaders reads the lists saved by the last RollupLeaders for the
+// month, the same as the Mongo store.
+func (store *memoryStore) AggregateLeaders(date time.Time, list string, limit int) ([]util.GamesStats, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	firstDay, lastDay := util.MonthRange(date)
+
+	var leaders []util.GamesStats
+	for _, rollup := range store.leaders {
+		if rollup.Date.Before(firstDay) || rollup.Date.After(lastDay) {
+			continue
+		}
+
+		switch list {
+		case "solved":
+			leaders = append(leaders, rollup.Solved...)
+		case "created":
+			leaders = append(leaders, rollup.Created...)
+		case "usersSolved":
+			leaders = append(leaders, rollup.UsersSolved...)
+		}
+	}
+
+	sortLeaders(leaders)
+
+	if limit != -1 && len(leaders) > limit {
+		leaders = leaders[:limit]
+	}
+
+	return leaders, nil
+}
+
+// RollupLeaders works out the month's leader lists from the games stored
+// now and saves them for AggregateLeaders.
+func (store *memoryStore) RollupLeaders(date time.Time) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	firstDay, lastDay := util.MonthRange(date)
+	inMonth := func(at time.Time) bool {
+		return !at.Before(firstDay) && !at.After(lastDay)
+	}
+
+	created := make(map[string]int)
+	solved := make(map[string]int)
+	solvers := make(map[string]map[string]bool)
+
+	for _, game := range store.games {
+		if inMonth(game.Date) {
+			created[game.User]++
+		}
+
+		for _, leader := range game.Leaderboard {
+			if !inMonth(leader.Date) {
+				continue
+			}
+
+			solved[leader.User]++
+
+			if solvers[game.User] == nil {
+				solvers[game.User] = make(map[string]bool)
+			}
+			solvers[game.User][leader.User] = true
+		}
+	}
+
+	usersSolved := make(map[string]int)
+	for creator, users := range solvers {
+		usersSolved[creator] = len(users)
+	}
+
+	rollup := util.Leaders{
+		Date:        firstDay,
+		Solved:      countLeaders(solved),
+		Created:     countLeaders(created),
+		UsersSolved: countLeaders(usersSolved),
+	}
+
+	for i, existing := range store.leaders {
+		if existing.Date.Equal(firstDay) {
+			store.leaders[i] = rollup
+			return nil
+		}
+	}
+
+	store.leaders = append(store.leaders, rollup)
+	return nil
+}
+
+func (store *memoryStore) RecordScore(id primitive.ObjectID, score Score) error {
+	_, err := store.update(id, func(game *Game) bool {
+		for i, existing := range game.Scores {
+			if existing.User == score.User {
+				game.Scores[i] = score
+				return true
+			}
+		}
+
+		game.Scores = append(game.Scores, score)
+		return true
+	})
+
+	return err
+}
+
+func claimIn(finds *[]FirstFind, find FirstFind) bool {
+	for _, existing := range *finds {
+		if existing.Word == find.Word {
+			return false
+		}
+	}
+
+	*finds = append(*finds, find)
+	return true
+}
+
+func (store *memoryStore) ClaimFirst(id primitive.ObjectID, find FirstFind) (bool, error) {
+	return store.update(id, func(game *Game) bool {
+		return game.Challenge != nil && claimIn(&game.Challenge.Firsts, find)
+	})
+}
+
+func (store *memoryStore) ClaimShared(id primitive.ObjectID, find FirstFind) (bool, error) {
+	return store.update(id, func(game *Game) bool {
+		return game.Shared != nil && claimIn(&game.Shared.Found, find)
+	})
+}
+
+func (store *memoryStore) SetExpiry(id primitive.ObjectID, expiresAt time.Time) error {
+	_, err := store.update(id, func(game *Game) bool {
+		game.ExpiresAt = expiresAt
+		return true
+	})
+
+	return err
+}
+
+func (store *memoryStore) CloseGame(id primitive.ObjectID, closedAt time.Time) (bool, error) {
+	return store.update(id, func(game *Game) bool {
+		if !game.Active {
+			return false
+		}
+
+		game.Active = false
+		game.ClosedAt = closedAt
+		return true
+	})
+}
+
+func (store *memoryStore) CloseChallenge(id primitive.ObjectID, closedAt time.Time, winner string) (bool, error) {
+	return store.update(id, func(game *Game) bool {
+		if !game.Active || game.Challenge == nil {
+			return false
+		}
+
+		game.Active = false
+		game.ClosedAt = closedAt
+		game.Challenge.Winner = winner
+		return true
+	})
+}
+
+func (store *memoryStore) CloseDailyGames(except primitive.ObjectID, closedAt time.Time) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for id, game := range store.games {
+		if game.Daily && game.Active && id != except {
+			game.Active = false
+			game.ClosedAt = closedAt
+			store.games[id] = game
+		}
+	}
+
+	return nil
+}
+
+func (store *memoryStore) GetProgress(game primitive.ObjectID, user string) (Progress, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	progress, ok := store.progress[progressKey{game, user}]
+
+	if !ok {
+		return Progress{Game: game, User: user}, nil
+	}
+
+	return copyProgress(progress), nil
+}
+
+// updateProgress changes a user's progress, starting it if they had none.
+func (store *memoryStore) updateProgress(game primitive.ObjectID, user string, change func(progress *Progress)) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	key := progressKey{game, user}
+	progress, ok := store.progress[key]
+
+	if !ok {
+		progress = Progress{Id: primitive.NewObjectID(), Game: game, User: user, Started: time.Now()}
+	}
+
+	progress = copyProgress(progress)
+	change(&progress)
+	store.progress[key] = progress
+}
+
+func (store *memoryStore) AddFoundWord(game primitive.ObjectID, user string, found engine.FoundWord) error {
+	store.updateProgress(game, user, func(progress *Progress) {
+		for _, word := range progress.Words {
+			if word.Word == found.Word {
+				return
+			}
+		}
+
+		progress.Words = append(progress.Words, found)
+		progress.Updated = found.Date
+	})
+
+	return nil
+}
+
+func (store *memoryStore) MarkSolved(game primitive.ObjectID, user string, at time.Time) error {
+	store.updateProgress(game, user, func(progress *Progress) {
+		progress.Solved = true
+		progress.Updated = at
+	})
+
+	return nil
+}
+
+func (store *memoryStore) UseHint(game primitive.ObjectID, user string, hint Hint, at time.Time) error {
+	store.updateProgress(game, user, func(progress *Progress) {
+		progress.Hint = &hint
+		progress.HintsUsed++
+		progress.Updated = at
+	})
+
+	return nil
+}
+
+func (store *memoryStore) MarkRevealed(game primitive.ObjectID, user string, at time.Time) error {
+	store.updateProgress(game, user, func(progress *Progress) {
+		progress.Revealed = true
+		progress.Updated = at
+	})
+
+	return nil
+}
+
+func (store *memoryStore) StartAttempt(game primitive.ObjectID, user string, started time.Time, deadline time.Time) (Progress, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	key := progressKey{game, user}
+	progress, ok := store.progress[key]
+
+	if !ok {
+		progress = Progress{
+			Id:       primitive.NewObjectID(),
+			Game:     game,
+			User:     user,
+			Started:  started,
+			Updated:  started,
+			Deadline: deadline,
+		}
+		store.progress[key] = progress
+	}
+
+	return copyProgress(progress), nil
+}
+
+func (store *memoryStore) FindProgress(user string, limit int64) ([]Progress, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var progresses []Progress
+	for _, progress := range store.progress {
+		if progress.User == user && !progress.Solved && !progress.Revealed {
+			progresses = append(progresses, copyProgress(progress))
+		}
+	}
+
+	sort.Slice(progresses, func(i, j int) bool {
+		return progresses[i].Updated.After(progresses[j].Updated)
+	})
+
+	if limit > 0 && int64(len(progresses)) > limit {
+		progresses = progresses[:limit]
+	}
+
+	return progresses, nil
+}
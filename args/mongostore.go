@@ -0,0 +1,348 @@
+package args
+
+import (
+	"context"
+	"time"
+
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoStore struct {
+	db *mongo.Database
+}
+
+// NewMongoStore keeps games in the games, progress and leaders collections
+// of db.
+func NewMongoStore(db *mongo.Database) Store {
+	return &mongoStore{db: db}
+}
+
+func (store *mongoStore) games() *mongo.Collection {
+	return store.db.Collection("games")
+}
+
+func (store *mongoStore) progress() *mongo.Collection {
+	return store.db.Collection("progress")
+}
+
+func (query GameQuery) filter() bson.D {
+	filter := bson.D{}
+
+	if len(query.IDs) > 0 {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$in", Value: query.IDs}}})
+	}
+
+	if query.Active {
+		filter = append(filter, bson.E{Key: "active", Value: true})
+	}
+
+	if query.Public {
+		filter = append(filter, bson.E{Key: "private", Value: bson.D{{Key: "$ne", Value: true}}})
+	}
+
+	if query.Creator != "" {
+		filter = append(filter, bson.E{Key: "user", Value: query.Creator})
+	}
+
+	if query.Player != "" {
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "user", Value: query.Player}},
+			bson.D{{Key: "challenge.players", Value: query.Player}},
+		}})
+	}
+
+	if query.VisibleTo != "" {
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "private", Value: bson.D{{Key: "$ne", Value: true}}}},
+			bson.D{{Key: "user", Value: query.VisibleTo}},
+			bson.D{{Key: "challenge.players", Value: query.VisibleTo}},
+		}})
+	}
+
+	if query.SolvedBy != "" {
+		filter = append(filter, bson.E{Key: "leaderboard.user", Value: query.SolvedBy})
+	}
+
+	if query.UnsolvedBy != "" {
+		filter = append(filter, bson.E{Key: "leaderboard.user", Value: bson.D{{Key: "$ne", Value: query.UnsolvedBy}}})
+	}
+
+	if !query.ExpiredBy.IsZero() {
+		filter = append(filter, bson.E{Key: "expiresAt", Value: bson.D{{Key: "$lte", Value: query.ExpiredBy}}})
+	}
+
+	if query.MissingExpiry {
+		filter = append(filter,
+			bson.E{Key: "expiration", Value: bson.D{{Key: "$exists", Value: true}, {Key: "$ne", Value: ""}}},
+			bson.E{Key: "expiresAt", Value: bson.D{{Key: "$exists", Value: false}}},
+		)
+	}
+
+	if query.Challenge {
+		filter = append(filter, bson.E{Key: "challenge", Value: bson.D{{Key: "$exists", Value: true}}})
+	}
+
+	if query.Daily {
+		filter = append(filter, bson.E{Key: "daily", Value: true})
+	}
+
+	if query.Solo {
+		filter = append(filter, bson.E{Key: "shared", Value: bson.D{{Key: "$exists", Value: false}}})
+	}
+
+	return filter
+}
+
+func (store *mongoStore) FindGames(query GameQuery) ([]Game, error) {
+	opts := options.Find()
+
+	switch query.Sort {
+	case SortNewest:
+		opts.SetSort(bson.D{{Key: "date", Value: -1}})
+	case SortRecentlySolved:
+		opts.SetSort(bson.D{{Key: "leaderboard.date", Value: -1}})
+	}
+
+	if query.Limit > 0 {
+		opts.SetLimit(query.Limit)
+	}
+
+	cursor, err := store.games().Find(context.TODO(), query.filter(), opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var games []Game
+	err = cursor.All(context.TODO(), &games)
+
+	return games, err
+}
+
+func (store *mongoStore) InsertGame(game Game) (Game, error) {
+	if game.Id.IsZero() {
+		game.Id = primitive.NewObjectID()
+	}
+
+	_, err := store.games().InsertOne(context.TODO(), game)
+	return game, err
+}
+
+func (store *mongoStore) GetGame(id primitive.ObjectID) (Game, error) {
+	var game Game
+	err := store.games().FindOne(context.TODO(), bson.D{{Key: "_id", Value: id}}).Decode(&game)
+
+	if err == mongo.ErrNoDocuments {
+		return game, ErrNotFound
+	}
+
+	return game, err
+}
+
+func (store *mongoStore) AddLeader(id primitive.ObjectID, leader Leaderboard) error {
+	update := bson.D{{Key: "$addToSet", Value: bson.D{{Key: "leaderboard", Value: leader}}}}
+
+	_, err := store.games().UpdateByID(context.TODO(), id, update)
+	return err
+}
+
+func (store *mongoStore) AggregateLeaders(date time.Time, list string, limit int) ([]util.GamesStats, error) {
+	return util.AggregateLeaders(store.db, date, list, limit)
+}
+
+func (store *mongoStore) RollupLeaders(date time.Time) error {
+	return util.RollupLeaders(store.db, date)
+}
+
+func (store *mongoStore) RecordScore(id primitive.ObjectID, score Score) error {
+	filter := bson.D{{Key: "_id", Value: id}, {Key: "scores.user", Value: score.User}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "scores.$.points", Value: score.Points},
+		{Key: "scores.$.words", Value: score.Words},
+		{Key: "scores.$.date", Value: score.Date},
+	}}}
+
+	result, err := store.games().UpdateOne(context.TODO(), filter, update)
+
+	if err == nil && result.MatchedCount == 0 {
+		push := bson.D{{Key: "$push", Value: bson.D{{Key: "scores", Value: score}}}}
+		_, err = store.games().UpdateByID(context.TODO(), id, push)
+	}
+
+	return err
+}
+
+// claim pushes find onto the list at field unless the word is already
+// there, so two players can't both claim it.
+func (store *mongoStore) claim(id primitive.ObjectID, field string, find FirstFind) (bool, error) {
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: field + ".word", Value: bson.D{{Key: "$ne", Value: find.Word}}},
+	}
+	update := bson.D{{Key: "$push", Value: bson.D{{Key: field, Value: find}}}}
+
+	result, err := store.games().UpdateOne(context.TODO(), filter, update)
+
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+func (store *mongoStore) ClaimFirst(id primitive.ObjectID, find FirstFind) (bool, error) {
+	return store.claim(id, "challenge.firsts", find)
+}
+
+func (store *mongoStore) ClaimShared(id primitive.ObjectID, find FirstFind) (bool, error) {
+	return store.claim(id, "shared.found", find)
+}
+
+func (store *mongoStore) SetExpiry(id primitive.ObjectID, expiresAt time.Time) error {
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "expiresAt", Value: expiresAt}}}}
+
+	_, err := store.games().UpdateByID(context.TODO(), id, update)
+	return err
+}
+
+// close sets fields on a game if it is still active.
+func (store *mongoStore) close(id primitive.ObjectID, set bson.D) (bool, error) {
+	filter := bson.D{{Key: "_id", Value: id}, {Key: "active", Value: true}}
+	update := bson.D{{Key: "$set", Value: append(bson.D{{Key: "active", Value: false}}, set...)}}
+
+	result, err := store.games().UpdateOne(context.TODO(), filter, update)
+
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+func (store *mongoStore) CloseGame(id primitive.ObjectID, closedAt time.Time) (bool, error) {
+	return store.close(id, bson.D{{Key: "closedAt", Value: closedAt}})
+}
+
+func (store *mongoStore) CloseChallenge(id primitive.ObjectID, closedAt time.Time, winner string) (bool, error) {
+	return store.close(id, bson.D{
+		{Key: "closedAt", Value: closedAt},
+		{Key: "challenge.winner", Value: winner},
+	})
+}
+
+func (store *mongoStore) CloseDailyGames(except primitive.ObjectID, closedAt time.Time) error {
+	filter := bson.D{
+		{Key: "_id", Value: bson.D{{Key: "$ne", Value: except}}},
+		{Key: "daily", Value: true},
+		{Key: "active", Value: true},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "active", Value: false},
+		{Key: "closedAt", Value: closedAt},
+	}}}
+
+	_, err := store.games().UpdateMany(context.TODO(), filter, update)
+	return err
+}
+
+func progressFilter(gameId primitive.ObjectID, user string) bson.D {
+	return bson.D{{Key: "game", Value: gameId}, {Key: "user", Value: user}}
+}
+
+func (store *mongoStore) GetProgress(game primitive.ObjectID, user string) (Progress, error) {
+	progress := Progress{Game: game, User: user}
+
+	err := store.progress().FindOne(context.TODO(), progressFilter(game, user)).Decode(&progress)
+
+	if err == mongo.ErrNoDocuments {
+		return progress, nil
+	}
+
+	return progress, err
+}
+
+func (store *mongoStore) updateProgress(gameId primitive.ObjectID, user string, update bson.D) error {
+	now := time.Now()
+	update = append(update,
+		bson.E{Key: "$setOnInsert", Value: bson.D{{Key: "started", Value: now}}},
+	)
+
+	_, err := store.progress().UpdateOne(context.TODO(), progressFilter(gameId, user), update, options.Update().SetUpsert(true))
+	return err
+}
+
+// AddFoundWord makes sure the progress exists, then only pushes the word
+// if it isn't already there, so a guess sent twice counts once.
+func (store *mongoStore) AddFoundWord(game primitive.ObjectID, user string, found engine.FoundWord) error {
+	if err := store.updateProgress(game, user, bson.D{}); err != nil {
+		return err
+	}
+
+	filter := append(progressFilter(game, user), bson.E{Key: "words.word", Value: bson.D{{Key: "$ne", Value: found.Word}}})
+	update := bson.D{
+		{Key: "$push", Value: bson.D{{Key: "words", Value: found}}},
+		{Key: "$set", Value: bson.D{{Key: "updated", Value: found.Date}}},
+	}
+
+	_, err := store.progress().UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+func (store *mongoStore) MarkSolved(game primitive.ObjectID, user string, at time.Time) error {
+	return store.updateProgress(game, user, bson.D{
+		{Key: "$set", Value: bson.D{{Key: "solved", Value: true}, {Key: "updated", Value: at}}},
+	})
+}
+
+func (store *mongoStore) UseHint(game primitive.ObjectID, user string, hint Hint, at time.Time) error {
+	return store.updateProgress(game, user, bson.D{
+		{Key: "$set", Value: bson.D{{Key: "hint", Value: hint}, {Key: "updated", Value: at}}},
+		{Key: "$inc", Value: bson.D{{Key: "hintsUsed", Value: 1}}},
+	})
+}
+
+func (store *mongoStore) MarkRevealed(game primitive.ObjectID, user string, at time.Time) error {
+	return store.updateProgress(game, user, bson.D{
+		{Key: "$set", Value: bson.D{{Key: "revealed", Value: true}, {Key: "updated", Value: at}}},
+	})
+}
+
+func (store *mongoStore) StartAttempt(game primitive.ObjectID, user string, started time.Time, deadline time.Time) (Progress, error) {
+	update := bson.D{{Key: "$setOnInsert", Value: bson.D{
+		{Key: "words", Value: []engine.FoundWord{}},
+		{Key: "started", Value: started},
+		{Key: "updated", Value: started},
+		{Key: "deadline", Value: deadline},
+	}}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var progress Progress
+	err := store.progress().FindOneAndUpdate(context.TODO(), progressFilter(game, user), update, opts).Decode(&progress)
+
+	return progress, err
+}
+
+func (store *mongoStore) FindProgress(user string, limit int64) ([]Progress, error) {
+	filter := bson.D{
+		{Key: "user", Value: user},
+		{Key: "solved", Value: bson.D{{Key: "$ne", Value: true}}},
+		{Key: "revealed", Value: bson.D{{Key: "$ne", Value: true}}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "updated", Value: -1}}).SetLimit(limit)
+
+	cursor, err := store.progress().Find(context.TODO(), filter, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var progresses []Progress
+	err = cursor.All(context.TODO(), &progresses)
+
+	return progresses, err
+}
@@ -0,0 +1,146 @@
+package args
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Progress is one user's attempt at one game, kept so they can close the
+// play modal and pick up where they left off.
+type Progress struct {
+	Id        primitive.ObjectID `bson:"_id,omitempty"`
+	Game      primitive.ObjectID `bson:"game"`
+	User      string             `bson:"user"`
+	Words     []engine.FoundWord `bson:"words"`
+	Started   time.Time          `bson:"started"`
+	Updated   time.Time          `bson:"updated"`
+	Solved    bool               `bson:"solved,omitempty"`
+	Hint      *Hint              `bson:"hint,omitempty"`
+	HintsUsed int                `bson:"hintsUsed,omitempty"`
+	Revealed  bool               `bson:"revealed,omitempty"`
+	Deadline  time.Time          `bson:"deadline,omitempty"`
+}
+
+// getProgress returns the user's progress on a game, or an empty one if they
+// haven't found anything yet.
+func (app *App) getProgress(gameId primitive.ObjectID, user string) Progress {
+	progress, err := app.store.GetProgress(gameId, user)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	return progress
+}
+
+func (progress Progress) found() []string {
+	var words []string
+	for _, found := range progress.Words {
+		words = append(words, found.Word)
+	}
+
+	return words
+}
+
+func (app *App) addFoundWord(gameId primitive.ObjectID, user string, found engine.FoundWord) error {
+	err := app.store.AddFoundWord(gameId, user, found)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	return err
+}
+
+func (app *App) markSolved(gameId primitive.ObjectID, user string) error {
+	err := app.store.MarkSolved(gameId, user, time.Now())
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	return err
+}
+
+// getInProgress returns the user's unsolved games that are still playable,
+// most recently played first.
+func (app *App) getInProgress(user string, limit int64) ([]Progress, map[primitive.ObjectID]Game) {
+	progresses, err := app.store.FindProgress(user, limit*2)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		return nil, nil
+	}
+
+	var ids []primitive.ObjectID
+	for _, progress := range progresses {
+		ids = append(ids, progress.Game)
+	}
+
+	games := make(map[primitive.ObjectID]Game)
+	if len(ids) == 0 {
+		return nil, games
+	}
+
+	found, err := app.store.FindGames(GameQuery{IDs: ids})
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		return nil, games
+	}
+
+	now := time.Now()
+	for _, game := range found {
+		if game.playable(now) {
+			games[game.Id] = game
+		}
+	}
+
+	var playable []Progress
+	for _, progress := range progresses {
+		if _, ok := games[progress.Game]; ok && !progress.sprintOver(now) && int64(len(playable)) < limit {
+			playable = append(playable, progress)
+		}
+	}
+
+	return playable, games
+}
+
+// continueSection lists the games the user has started but not finished,
+// each with a button that reopens the play modal where they left off.
+func (app *App) continueSection(user string) []slack.Block {
+	progresses, games := app.getInProgress(user, 5)
+
+	if len(progresses) == 0 {
+		return nil
+	}
+
+	header := slack.NewHeaderBlock(slack.NewTextBlockObject("plain_text", "Continue where you left off", false, false))
+	blocks := []slack.Block{
+		slack.NewDividerBlock(),
+		header,
+	}
+
+	for _, progress := range progresses {
+		game := games[progress.Game]
+		gameID := game.Id.Hex()
+		_, _, creator := getUser(game.User)
+
+		message := "*" + strings.ToUpper(game.Letters) + "* - " + creator + "\n" + strconv.Itoa(len(progress.Words)) + " of " + strconv.Itoa(len(game.Words)) + " words found"
+		messageBlock := slack.NewTextBlockObject("mrkdwn", message, false, false)
+		buttonText := slack.NewTextBlockObject("plain_text", "Continue", false, false)
+		button := slack.NewButtonBlockElement("continue-"+gameID, gameID, buttonText)
+		section := slack.NewSectionBlock(messageBlock, nil, slack.NewAccessory(button))
+		section.BlockID = "continue-" + gameID
+
+		blocks = append(blocks, section)
+	}
+
+	return blocks
+}
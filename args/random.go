@@ -0,0 +1,106 @@
+package args
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/slack-go/slack"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/slices"
+)
+
+const defaultRandomLetters = 7
+
+// minRandomLetters is the fewest letters worth picking at random. The most
+// is slices.MaxLetters.
+const minRandomLetters = 4
+
+var randomMinWords = envInt("RANDOM_MIN_WORDS", 15)
+var randomMaxWords = envInt("RANDOM_MAX_WORDS", 60)
+
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+func surpriseInput() *slack.InputBlock {
+	var options []*slack.OptionBlockObject
+	for n := minRandomLetters; n <= 9; n++ {
+		text := slack.NewTextBlockObject("plain_text", strconv.Itoa(n)+" letters", false, false)
+		options = append(options, slack.NewOptionBlockObject(strconv.Itoa(n), text, nil))
+	}
+
+	placeholder := slack.NewTextBlockObject("plain_text", "Number of letters", false, false)
+	surpriseSelect := slack.NewOptionsSelectBlockElement("static_select", placeholder, "surprise", options...)
+	surpriseLabel := slack.NewTextBlockObject("plain_text", "Surprise me", false, false)
+	surpriseHint := slack.NewTextBlockObject("plain_text", "Pick a number of letters instead of typing them and we'll choose ones that make a good game", false, false)
+	surpriseBlock := slack.NewInputBlock("surprise", surpriseLabel, surpriseHint, surpriseSelect)
+	surpriseBlock.Optional = true
+
+	return surpriseBlock
+}
+
+// commandOptions reads the letters typed after a command, or asks for
+// defaultRandomLetters random ones when there aren't any.
+func commandOptions(params []string) engine.CreateOptions {
+	if len(params) > 0 {
+		return engine.CreateOptions{Letters: params[0], Mode: engine.ModeReuse}
+	}
+
+	return engine.CreateOptions{Random: defaultRandomLetters, Mode: engine.ModeReuse}
+}
+
+// createErrorText explains why a game asked for with a command couldn't be
+// created.
+func createErrorText(game Game, options engine.CreateOptions, err error) string {
+	switch err {
+	case engine.ErrNoRandomLetters:
+		return "Couldn't find " + strconv.Itoa(options.Random) + " letters that make between " + strconv.Itoa(randomMinWords) + " and " + strconv.Itoa(randomMaxWords) + " words :cry:  Try a different number!"
+	case engine.ErrNoLetters, engine.ErrNoWords:
+		return "No words found with letters '" + game.Letters + "'!  Try another combination!"
+	}
+
+	return "Couldn't create the game :cry:"
+}
+
+func (app *App) createRandomGame(res http.ResponseWriter, command slack.SlashCommand, params []string) {
+	n := defaultRandomLetters
+
+	if len(params) > 0 {
+		var err error
+		n, err = strconv.Atoi(params[0])
+
+		if err != nil || n < minRandomLetters || n > slices.MaxLetters {
+			res.Write([]byte("`/angrms create random [n]` needs a number of letters between " + strconv.Itoa(minRandomLetters) + " and " + strconv.Itoa(slices.MaxLetters)))
+			return
+		}
+	}
+
+	options := engine.CreateOptions{Random: n, Mode: engine.ModeReuse}
+	game, err := newGame(command.TeamID, options)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		res.Write([]byte(createErrorText(game, options, err)))
+		return
+	}
+
+	game.User = command.UserName
+
+	if err := app.insertGame(game); err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	app.announceCreatedGame(game)
+
+	res.Write([]byte("You created a game with the letters *" + strings.ToUpper(game.Letters) + "* that has " + strconv.Itoa(len(game.Words)) + " words to find! 🚀🚀🚀"))
+}
@@ -0,0 +1,123 @@
+package args
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Section text is capped at 3000 characters, so long word lists are split
+// over several sections.
+const sectionTextLimit = 2900
+
+func revealButton() *slack.Accessory {
+	buttonText := slack.NewTextBlockObject("plain_text", "Reveal answers", false, false)
+	button := slack.NewButtonBlockElement("reveal", "reveal", buttonText)
+
+	confirmTitle := slack.NewTextBlockObject("plain_text", "Give up?", false, false)
+	confirmText := slack.NewTextBlockObject("plain_text", "You'll see every word, but you won't be able to keep playing or get on this game's leaderboard.", false, false)
+	confirmYes := slack.NewTextBlockObject("plain_text", "Reveal", false, false)
+	confirmNo := slack.NewTextBlockObject("plain_text", "Keep playing", false, false)
+	button.Confirm = slack.NewConfirmationBlockObject(confirmTitle, confirmText, confirmYes, confirmNo)
+
+	return slack.NewAccessory(button)
+}
+
+// textSections joins lines into as few mrkdwn sections as fit.
+func textSections(lines []string) []slack.Block {
+	var blocks []slack.Block
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() == 0 {
+			return
+		}
+
+		textBlock := slack.NewTextBlockObject("mrkdwn", text.String(), false, false)
+		blocks = append(blocks, slack.NewSectionBlock(textBlock, nil, nil))
+		text.Reset()
+	}
+
+	for _, line := range lines {
+		if text.Len()+len(line)+1 > sectionTextLimit {
+			flush()
+		}
+
+		if text.Len() > 0 {
+			text.WriteString("\n")
+		}
+
+		text.WriteString(line)
+	}
+
+	flush()
+	return blocks
+}
+
+// wordListSections lists every word in the game, marking the ones in found.
+func wordListSections(words []string, found []string) []slack.Block {
+	var lines []string
+
+	for _, word := range words {
+		line := word
+		if alreadyGuessed(found, word) {
+			line = ":white_check_mark: *" + word + "*"
+		}
+
+		lines = append(lines, line)
+	}
+
+	return textSections(lines)
+}
+
+// summaryView shows every word in a game with the viewer's finds ticked off.
+func summaryView(game Game, found []string, title string, message string) slack.ModalViewRequest {
+	var view slack.ModalViewRequest
+	view.Type = slack.ViewType("modal")
+	view.Title = slack.NewTextBlockObject("plain_text", title, false, false)
+	view.Close = slack.NewTextBlockObject("plain_text", "Close", false, false)
+	view.ClearOnClose = true
+
+	messageBlock := slack.NewTextBlockObject("plain_text", message, false, false)
+	tally := "You found " + strconv.Itoa(len(found)) + " of " + strconv.Itoa(len(game.Words)) + " words in *" + strings.ToUpper(game.Letters) + "*"
+	tallyBlock := slack.NewTextBlockObject("mrkdwn", tally, false, false)
+
+	view.Blocks.BlockSet = []slack.Block{
+		slack.NewSectionBlock(messageBlock, nil, nil),
+		slack.NewSectionBlock(tallyBlock, nil, nil),
+		slack.NewDividerBlock(),
+	}
+	view.Blocks.BlockSet = append(view.Blocks.BlockSet, wordListSections(game.Words, found)...)
+
+	return view
+}
+
+func (app *App) revealAnswers(req slack.InteractionCallback, res http.ResponseWriter) {
+	gameId, err := primitive.ObjectIDFromHex(req.View.PrivateMetadata)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	game := app.getGame(gameId)
+	user := req.User.Name
+	found := app.playerProgress(game, user).found()
+
+	if err := app.store.MarkRevealed(gameId, user, time.Now()); err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	view := summaryView(game, found, "Answers", "Here are all the words. Better luck next time!")
+	apiRes, err := app.api.UpdateView(view, "", req.Hash, req.View.ID)
+
+	if err != nil {
+		fmt.Printf("%+v", apiRes)
+	}
+}
@@ -0,0 +1,128 @@
+package args
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/slack-go/slack"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+)
+
+func ruleInputs() []slack.Block {
+	minLabel := slack.NewTextBlockObject("plain_text", "Minimum word length", false, false)
+	minPlaceholder := slack.NewTextBlockObject("plain_text", "4", false, false)
+	minInput := slack.NewPlainTextInputBlockElement(minPlaceholder, "minLength")
+	minBlock := slack.NewInputBlock("minLength", minLabel, nil, minInput)
+	minBlock.Optional = true
+
+	maxLabel := slack.NewTextBlockObject("plain_text", "Maximum word length", false, false)
+	maxPlaceholder := slack.NewTextBlockObject("plain_text", "8", false, false)
+	maxInput := slack.NewPlainTextInputBlockElement(maxPlaceholder, "maxLength")
+	maxBlock := slack.NewInputBlock("maxLength", maxLabel, nil, maxInput)
+	maxBlock.Optional = true
+
+	centerMessage := "Required letter. Every word has to contain it, but the other letters become optional"
+	centerLabel := slack.NewTextBlockObject("plain_text", centerMessage, false, false)
+	centerHint := slack.NewTextBlockObject("plain_text", "Must be one of the letters above", false, false)
+	centerPlaceholder := slack.NewTextBlockObject("plain_text", "e", false, false)
+	centerInput := slack.NewPlainTextInputBlockElement(centerPlaceholder, "centerLetter")
+	centerInput.MaxLength = 1
+	centerBlock := slack.NewInputBlock("centerLetter", centerLabel, centerHint, centerInput)
+	centerBlock.Optional = true
+
+	return []slack.Block{
+		minBlock,
+		maxBlock,
+		centerBlock,
+	}
+}
+
+func parseLength(value string) (int, bool) {
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return 0, true
+	}
+
+	length, err := strconv.Atoi(value)
+
+	if err != nil || length < 1 {
+		return 0, false
+	}
+
+	return length, true
+}
+
+// parseRules reads the rule inputs from a create modal submission. Problems
+// are returned keyed by block ID, ready for a view submission error response.
+// Whether the required letter is one of the game's letters is left to the
+// engine.
+func parseRules(values map[string]map[string]slack.BlockAction) (engine.Rules, map[string]string) {
+	var rules engine.Rules
+	errors := make(map[string]string)
+
+	minLength, ok := parseLength(values["minLength"]["minLength"].Value)
+	if !ok {
+		errors["minLength"] = "Minimum length has to be a whole number above zero"
+	}
+
+	maxLength, ok := parseLength(values["maxLength"]["maxLength"].Value)
+	if !ok {
+		errors["maxLength"] = "Maximum length has to be a whole number above zero"
+	} else if minLength > 0 && maxLength > 0 && maxLength < minLength {
+		errors["maxLength"] = "Maximum length can't be shorter than the minimum"
+	}
+
+	center := strings.ToLower(strings.TrimSpace(values["centerLetter"]["centerLetter"].Value))
+
+	rules.MinLength = minLength
+	rules.MaxLength = maxLength
+	rules.CenterLetter = center
+
+	return rules, errors
+}
+
+func modeInput() *slack.InputBlock {
+	anagramText := slack.NewTextBlockObject("plain_text", "Anagram mode: each letter can only be used as many times as you typed it", false, false)
+	anagramOption := slack.NewOptionBlockObject(engine.ModeAnagram, anagramText, nil)
+	modeCheckBox := slack.NewCheckboxGroupsBlockElement("mode", anagramOption)
+	modeLabel := slack.NewTextBlockObject("plain_text", "Count letters?", false, false)
+	modeBlock := slack.NewInputBlock("mode", modeLabel, nil, modeCheckBox)
+	modeBlock.Optional = true
+
+	return modeBlock
+}
+
+func modeLabel(mode string) string {
+	if mode == engine.ModeAnagram {
+		return "anagram mode"
+	}
+
+	return ""
+}
+
+// describeRules sums up the rules for the play modal, or returns an empty
+// string when the game has none.
+func describeRules(rules engine.Rules) string {
+	var parts []string
+
+	switch {
+	case rules.MinLength > 0 && rules.MaxLength > 0:
+		parts = append(parts, "words are "+strconv.Itoa(rules.MinLength)+" to "+strconv.Itoa(rules.MaxLength)+" letters long")
+	case rules.MinLength > 0:
+		parts = append(parts, "words are at least "+strconv.Itoa(rules.MinLength)+" letters long")
+	case rules.MaxLength > 0:
+		parts = append(parts, "words are at most "+strconv.Itoa(rules.MaxLength)+" letters long")
+	}
+
+	if rules.CenterLetter != "" {
+		parts = append(parts, "every word contains *"+strings.ToUpper(rules.CenterLetter)+"*")
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	description := strings.Join(parts, " and ")
+	return strings.ToUpper(description[:1]) + description[1:]
+}
@@ -0,0 +1,53 @@
+package args
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+)
+
+type Score struct {
+	User   string    `bson:"user"`
+	Points int       `bson:"points"`
+	Words  int       `bson:"words"`
+	Date   time.Time `bson:"date"`
+}
+
+// recordScore saves the player's current score on the game, replacing any
+// score they already had there. Team games aren't scored per player.
+func (app *App) recordScore(game Game, user string, progress Progress) error {
+	if game.Shared != nil {
+		return nil
+	}
+
+	err := app.store.RecordScore(game.Id, Score{
+		User:   user,
+		Points: engine.Progress(game.Puzzle(), progress.Words, progress.HintsUsed).Points,
+		Words:  len(progress.Words),
+		Date:   time.Now(),
+	})
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+	}
+
+	return err
+}
+
+// rankedScores orders scores from most to fewest points. Ties go to whoever
+// got there first.
+func rankedScores(scores []Score) []Score {
+	ranked := append([]Score(nil), scores...)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Points != ranked[j].Points {
+			return ranked[i].Points > ranked[j].Points
+		}
+
+		return ranked[i].Date.Before(ranked[j].Date)
+	})
+
+	return ranked
+}
@@ -0,0 +1,192 @@
+package args
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+)
+
+// SharedGame is a game a whole channel solves together. Every correct
+// guess goes into one found set and the channel's pinned message keeps
+// score.
+type SharedGame struct {
+	Channel   string      `bson:"channel"`
+	MessageTS string      `bson:"messageTs,omitempty"`
+	Found     []FirstFind `bson:"found"`
+}
+
+func (shared *SharedGame) words() []engine.FoundWord {
+	var words []engine.FoundWord
+	for _, found := range shared.Found {
+		words = append(words, engine.FoundWord{Word: found.Word, Date: found.Date})
+	}
+
+	return words
+}
+
+func (shared *SharedGame) found() []string {
+	var words []string
+	for _, found := range shared.Found {
+		words = append(words, found.Word)
+	}
+
+	return words
+}
+
+// playerProgress is the progress to play a game from. For shared games the
+// found words are the channel's, while hints and reveals stay the player's.
+func (app *App) playerProgress(game Game, user string) Progress {
+	progress := app.getProgress(game.Id, user)
+
+	if game.Shared != nil {
+		progress.Words = game.Shared.words()
+	}
+
+	return progress
+}
+
+func (app *App) createSharedGame(res http.ResponseWriter, command slack.SlashCommand, params []string) {
+	options := commandOptions(params)
+	game, err := newGame(command.TeamID, options)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		res.Write([]byte(createErrorText(game, options, err)))
+		return
+	}
+
+	game.User = command.UserName
+	game.Shared = &SharedGame{
+		Channel: command.ChannelID,
+		Found:   make([]FirstFind, 0),
+	}
+
+	// Post first, so a channel Angrms can't post in doesn't end up with a
+	// game nobody can see.
+	_, ts, err := app.api.PostMessage(command.ChannelID, sharedMessage(game)...)
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		res.Write([]byte("Couldn't post to this channel.  Is Angrms a member of it?"))
+		return
+	}
+
+	game.Shared.MessageTS = ts
+
+	if err := app.insertGame(game); err != nil {
+		if _, _, err := app.api.DeleteMessage(command.ChannelID, ts); err != nil {
+			fmt.Printf("%+v", err)
+		}
+
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := app.api.AddPin(command.ChannelID, slack.NewRefToMessage(command.ChannelID, ts)); err != nil {
+		fmt.Printf("%+v", err)
+	}
+}
+
+// sharedMessage is the pinned scoreboard for a shared game.
+func sharedMessage(game Game) []slack.MsgOption {
+	shared := game.Shared
+	left := len(game.Words) - len(shared.Found)
+
+	headerText := ":busts_in_silhouette: *Team game: " + strings.ToUpper(game.Letters) + "*\n" + strconv.Itoa(len(shared.Found)) + " of " + strconv.Itoa(len(game.Words)) + " words found, " + strconv.Itoa(left) + " left.  Press *Play* to help out!"
+	if left == 0 {
+		headerText = ":tada: *Team game: " + strings.ToUpper(game.Letters) + "*\nEvery one of the " + strconv.Itoa(len(game.Words)) + " words has been found!"
+	}
+
+	headerBlock := slack.NewTextBlockObject("mrkdwn", headerText, false, false)
+	blocks := []slack.Block{
+		slack.NewSectionBlock(headerBlock, nil, nil),
+	}
+
+	if left > 0 {
+		blocks = append(blocks, slack.NewActionBlock("play-"+game.Id.Hex(), playButton(game)))
+	}
+
+	byUser := make(map[string][]string)
+	var users []string
+	for _, found := range shared.Found {
+		if _, ok := byUser[found.User]; !ok {
+			users = append(users, found.User)
+		}
+
+		byUser[found.User] = append(byUser[found.User], found.Word)
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		return len(byUser[users[i]]) > len(byUser[users[j]])
+	})
+
+	var lines []string
+	for _, user := range users {
+		_, _, name := getUser(user)
+		lines = append(lines, "*"+name+"* ("+strconv.Itoa(len(byUser[user]))+"): "+strings.Join(byUser[user], ", "))
+	}
+
+	if len(lines) > 0 {
+		blocks = append(blocks, slack.NewDividerBlock())
+		blocks = append(blocks, textSections(lines)...)
+	}
+
+	return []slack.MsgOption{
+		slack.MsgOptionBlocks(blocks...),
+		slack.MsgOptionText(headerText, false),
+	}
+}
+
+// claimShared adds word to the channel's found set. It returns false when
+// someone else got there first.
+func (app *App) claimShared(game Game, user string, found engine.FoundWord) bool {
+	claimed, err := app.store.ClaimShared(game.Id, FirstFind{Word: found.Word, User: user, Date: found.Date})
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+		return false
+	}
+
+	return claimed
+}
+
+// refreshShared updates the pinned scoreboard and, once every word is
+// found, closes the game and celebrates in the channel.
+func (app *App) refreshShared(game Game) {
+	game = app.getGame(game.Id)
+
+	if game.Shared == nil {
+		return
+	}
+
+	if game.Shared.MessageTS != "" {
+		_, _, _, err := app.api.UpdateMessage(game.Shared.Channel, game.Shared.MessageTS, sharedMessage(game)...)
+
+		if err != nil {
+			fmt.Printf("%+v", err)
+		}
+	}
+
+	if len(game.Shared.Found) < len(game.Words) {
+		return
+	}
+
+	closed, err := app.store.CloseGame(game.Id, time.Now())
+
+	if err != nil || !closed {
+		return
+	}
+
+	message := ":tada::tada::tada: <!here> this channel found every word in *" + strings.ToUpper(game.Letters) + "*!  Thanks to everyone who pitched in :confetti_ball:"
+	_, _, err = app.api.PostMessage(game.Shared.Channel, slack.MsgOptionText(message, false))
+
+	if err != nil {
+		fmt.Printf("%+v", err)
+	}
+}
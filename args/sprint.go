@@ -0,0 +1,95 @@
+package args
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+var sprintMinutes = []int{1, 2, 3, 5, 10}
+
+func sprintInput() *slack.InputBlock {
+	var options []*slack.OptionBlockObject
+	for _, minutes := range sprintMinutes {
+		text := slack.NewTextBlockObject("plain_text", sprintLength(minutes), false, false)
+		options = append(options, slack.NewOptionBlockObject(strconv.Itoa(minutes), text, nil))
+	}
+
+	placeholder := slack.NewTextBlockObject("plain_text", "No time limit", false, false)
+	sprintSelect := slack.NewOptionsSelectBlockElement("static_select", placeholder, "sprint", options...)
+	sprintLabel := slack.NewTextBlockObject("plain_text", "Sprint", false, false)
+	sprintHint := slack.NewTextBlockObject("plain_text", "Give each player one attempt, timed from when they open the game", false, false)
+	sprintBlock := slack.NewInputBlock("sprint", sprintLabel, sprintHint, sprintSelect)
+	sprintBlock.Optional = true
+
+	return sprintBlock
+}
+
+func sprintLength(minutes int) string {
+	if minutes == 1 {
+		return "1 minute"
+	}
+
+	return strconv.Itoa(minutes) + " minutes"
+}
+
+// startSprint starts the user's one attempt at a sprint game, or returns
+// the attempt they already started.
+func (app *App) startSprint(game Game, user string) (Progress, error) {
+	now := time.Now()
+	return app.store.StartAttempt(game.Id, user, now, now.Add(time.Duration(game.Sprint)*time.Minute))
+}
+
+func (progress Progress) sprintOver(now time.Time) bool {
+	return !progress.Deadline.IsZero() && now.After(progress.Deadline)
+}
+
+// sprintText counts down to the deadline. Slack shows the time in each
+// viewer's own timezone.
+func sprintText(progress Progress) string {
+	if progress.Deadline.IsZero() {
+		return ""
+	}
+
+	left := time.Until(progress.Deadline).Round(time.Minute)
+	minutes := int(left / time.Minute)
+	deadline := strconv.FormatInt(progress.Deadline.Unix(), 10)
+
+	text := ":stopwatch: Sprint ends at <!date^" + deadline + "^{time_secs}|" + progress.Deadline.Format(time.Kitchen) + ">"
+	if minutes > 0 {
+		text += ", about " + sprintLength(minutes) + " left"
+	}
+
+	return text
+}
+
+func sprintOverMessage(game Game) string {
+	return "Your " + sprintLength(game.Sprint) + " sprint is over :stopwatch:  Each player only gets one attempt at this game."
+}
+
+// timeUp replaces the play modal with the end of sprint summary.
+func (app *App) timeUp(req slack.InteractionCallback, res http.ResponseWriter, game Game, found []string) {
+	view := summaryView(game, found, "Time's up!", sprintOverMessage(game))
+
+	apiRes, err := app.api.UpdateView(view, "", req.Hash, req.View.ID)
+
+	if err != nil {
+		fmt.Printf("%+v", apiRes)
+	}
+}
+
+// rankedSprintScores orders sprint scores by the words found within each
+// player's sprint, then by points, then by who got there first.
+func rankedSprintScores(scores []Score) []Score {
+	ranked := rankedScores(scores)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Words > ranked[j].Words
+	})
+
+	return ranked
+}
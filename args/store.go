@@ -0,0 +1,102 @@
+package args
+
+import (
+	"errors"
+	"time"
+
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrNotFound = errors.New("not found")
+
+type GameSort int
+
+const (
+	// SortInserted keeps games in the order they were created.
+	SortInserted GameSort = iota
+	SortNewest
+	// SortRecentlySolved puts the most recently solved games first.
+	SortRecentlySolved
+)
+
+// GameQuery picks games out of a GameStore. Fields left at their zero value
+// don't filter anything.
+type GameQuery struct {
+	IDs    []primitive.ObjectID
+	Active bool
+	// Public leaves out private games.
+	Public bool
+	// Creator only matches games the user created.
+	Creator string
+	// Player matches games the user created or was challenged to.
+	Player string
+	// VisibleTo matches public games and the ones Player would.
+	VisibleTo  string
+	SolvedBy   string
+	UnsolvedBy string
+	// ExpiredBy matches games with an expiration at or before it.
+	ExpiredBy time.Time
+	// MissingExpiry matches games saved with an expiration before its end
+	// time was stored alongside it.
+	MissingExpiry bool
+	Challenge     bool
+	Daily         bool
+	// Solo leaves out team games, which are played from their channel.
+	Solo  bool
+	Sort  GameSort
+	Limit int64
+}
+
+// GameStore keeps games and the monthly leaders worked out from them.
+type GameStore interface {
+	FindGames(query GameQuery) ([]Game, error)
+	// InsertGame saves a new game, giving it an ID if it doesn't have one.
+	InsertGame(game Game) (Game, error)
+	GetGame(id primitive.ObjectID) (Game, error)
+	AddLeader(id primitive.ObjectID, leader Leaderboard) error
+	// AggregateLeaders ranks one of the monthly leader lists ("solved",
+	// "created" or "usersSolved") for the month containing date. A limit of
+	// -1 returns everyone. The lists are only as fresh as the last
+	// RollupLeaders for that month.
+	AggregateLeaders(date time.Time, list string, limit int) ([]util.GamesStats, error)
+	// RollupLeaders works out and saves the leader lists for the month
+	// containing date.
+	RollupLeaders(date time.Time) error
+
+	// RecordScore saves a player's score, replacing any they already had.
+	RecordScore(id primitive.ObjectID, score Score) error
+	// ClaimFirst and ClaimShared record who found a word first in a
+	// challenge or team game. They return false if someone already had.
+	ClaimFirst(id primitive.ObjectID, find FirstFind) (bool, error)
+	ClaimShared(id primitive.ObjectID, find FirstFind) (bool, error)
+	SetExpiry(id primitive.ObjectID, expiresAt time.Time) error
+	// CloseGame and CloseChallenge deactivate a game, returning false if it
+	// was already closed.
+	CloseGame(id primitive.ObjectID, closedAt time.Time) (bool, error)
+	CloseChallenge(id primitive.ObjectID, closedAt time.Time, winner string) (bool, error)
+	// CloseDailyGames closes every active daily game except the one given.
+	CloseDailyGames(except primitive.ObjectID, closedAt time.Time) error
+}
+
+// ProgressStore keeps each player's attempt at each game.
+type ProgressStore interface {
+	// GetProgress returns an empty Progress if the user hasn't started.
+	GetProgress(game primitive.ObjectID, user string) (Progress, error)
+	AddFoundWord(game primitive.ObjectID, user string, found engine.FoundWord) error
+	MarkSolved(game primitive.ObjectID, user string, at time.Time) error
+	UseHint(game primitive.ObjectID, user string, hint Hint, at time.Time) error
+	MarkRevealed(game primitive.ObjectID, user string, at time.Time) error
+	// StartAttempt starts a timed attempt ending at deadline, or returns the
+	// attempt the user already started.
+	StartAttempt(game primitive.ObjectID, user string, started time.Time, deadline time.Time) (Progress, error)
+	// FindProgress returns the user's unsolved, unrevealed attempts, most
+	// recently played first.
+	FindProgress(user string, limit int64) ([]Progress, error)
+}
+
+type Store interface {
+	GameStore
+	ProgressStore
+}
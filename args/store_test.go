@@ -0,0 +1,332 @@
+package args
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, func(t *testing.T) Store {
+		return NewMemoryStore()
+	})
+}
+
+// TestMongoStore runs the same checks against a throwaway database when
+// MONGO_HOST is set.
+func TestMongoStore(t *testing.T) {
+	if os.Getenv("MONGO_HOST") == "" {
+		t.Skip("MONGO_HOST isn't set")
+	}
+
+	testStore(t, func(t *testing.T) Store {
+		db := util.MongoClient().Database("slack_test_" + primitive.NewObjectID().Hex())
+		t.Cleanup(func() { db.Drop(context.TODO()) })
+
+		return NewMongoStore(db)
+	})
+}
+
+// storeNow is a UTC time in whole seconds, so it comes back from Mongo
+// unchanged.
+var storeNow = time.Date(2023, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+// testStore checks the behaviour every Store has to share, so the app acts
+// the same whichever one it runs on.
+func testStore(t *testing.T, newStore func(t *testing.T) Store) {
+	t.Run("InsertAndGet", func(t *testing.T) {
+		store := newStore(t)
+
+		game, err := store.InsertGame(Game{User: "jane.doe", Letters: "tab", Words: []string{"bat", "tab"}, Active: true, Date: storeNow})
+		if err != nil || game.Id.IsZero() {
+			t.Fatalf("insert: got %+v, %v", game, err)
+		}
+
+		got, err := store.GetGame(game.Id)
+		if err != nil || got.Letters != "tab" || !reflect.DeepEqual(got.Words, game.Words) || !got.Date.Equal(storeNow) {
+			t.Errorf("get: got %+v, %v", got, err)
+		}
+
+		if _, err := store.GetGame(primitive.NewObjectID()); err != ErrNotFound {
+			t.Errorf("missing game: got %v", err)
+		}
+	})
+
+	t.Run("FindGames", func(t *testing.T) {
+		store := newStore(t)
+		insert := func(game Game) primitive.ObjectID {
+			game, err := store.InsertGame(game)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return game.Id
+		}
+
+		old := insert(Game{User: "jane.doe", Active: true, Date: storeNow.Add(-2 * time.Hour), ExpiresAt: storeNow.Add(-time.Hour),
+			Leaderboard: []Leaderboard{{User: "john.doe", Date: storeNow}}})
+		private := insert(Game{User: "jane.doe", Active: true, Private: true, Date: storeNow.Add(-time.Hour), Expiration: "1d"})
+		challenge := insert(Game{User: "jane.doe", Active: true, Private: true, Date: storeNow,
+			Challenge: &Challenge{Players: []string{"jane.doe", "john.doe"}}})
+		daily := insert(Game{User: "angrms", Active: true, Daily: true, Date: storeNow.Add(time.Hour),
+			Leaderboard: []Leaderboard{{User: "john.doe", Date: storeNow.Add(-time.Hour)}}})
+		closed := insert(Game{User: "john.doe", Date: storeNow.Add(2 * time.Hour)})
+
+		tests := []struct {
+			name  string
+			query GameQuery
+			want  []primitive.ObjectID
+		}{
+			{"active", GameQuery{Active: true, Sort: SortNewest}, []primitive.ObjectID{daily, challenge, private, old}},
+			{"public", GameQuery{Public: true, Sort: SortNewest}, []primitive.ObjectID{closed, daily, old}},
+			{"creator", GameQuery{Creator: "john.doe"}, []primitive.ObjectID{closed}},
+			{"player", GameQuery{Player: "john.doe", Sort: SortNewest}, []primitive.ObjectID{closed, challenge}},
+			{"visible", GameQuery{VisibleTo: "john.doe", Sort: SortNewest}, []primitive.ObjectID{closed, daily, challenge, old}},
+			{"solved", GameQuery{SolvedBy: "john.doe", Sort: SortRecentlySolved}, []primitive.ObjectID{old, daily}},
+			{"unsolved", GameQuery{UnsolvedBy: "john.doe", Active: true, Sort: SortNewest}, []primitive.ObjectID{challenge, private}},
+			{"expired", GameQuery{ExpiredBy: storeNow}, []primitive.ObjectID{old}},
+			{"missing expiry", GameQuery{MissingExpiry: true}, []primitive.ObjectID{private}},
+			{"challenge", GameQuery{Challenge: true}, []primitive.ObjectID{challenge}},
+			{"daily", GameQuery{Daily: true}, []primitive.ObjectID{daily}},
+			{"ids", GameQuery{IDs: []primitive.ObjectID{old, closed}, Sort: SortNewest}, []primitive.ObjectID{closed, old}},
+			{"limit", GameQuery{Sort: SortNewest, Limit: 2}, []primitive.ObjectID{closed, daily}},
+		}
+
+		for _, test := range tests {
+			games, err := store.FindGames(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []primitive.ObjectID
+			for _, game := range games {
+				got = append(got, game.Id)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+			}
+		}
+	})
+
+	t.Run("Updates", func(t *testing.T) {
+		store := newStore(t)
+		game, _ := store.InsertGame(Game{User: "jane.doe", Active: true, Date: storeNow})
+
+		leader := Leaderboard{User: "john.doe", Date: storeNow}
+		store.AddLeader(game.Id, leader)
+		store.AddLeader(game.Id, leader)
+
+		store.RecordScore(game.Id, Score{User: "john.doe", Points: 3, Words: 1, Date: storeNow})
+		store.RecordScore(game.Id, Score{User: "john.doe", Points: 8, Words: 2, Date: storeNow})
+
+		store.SetExpiry(game.Id, storeNow.Add(time.Hour))
+
+		game, _ = store.GetGame(game.Id)
+
+		if len(game.Leaderboard) != 1 {
+			t.Errorf("leaderboard %+v", game.Leaderboard)
+		}
+
+		if len(game.Scores) != 1 || game.Scores[0].Points != 8 || game.Scores[0].Words != 2 {
+			t.Errorf("scores %+v", game.Scores)
+		}
+
+		if !game.ExpiresAt.Equal(storeNow.Add(time.Hour)) {
+			t.Errorf("expiry %v", game.ExpiresAt)
+		}
+
+		if closed, err := store.CloseGame(game.Id, storeNow); !closed || err != nil {
+			t.Errorf("close: got %v, %v", closed, err)
+		}
+
+		if closed, _ := store.CloseGame(game.Id, storeNow); closed {
+			t.Error("closed a game twice")
+		}
+
+		game, _ = store.GetGame(game.Id)
+		if game.Active || !game.ClosedAt.Equal(storeNow) {
+			t.Errorf("closed game %+v", game)
+		}
+	})
+
+	t.Run("Challenges", func(t *testing.T) {
+		store := newStore(t)
+		game, _ := store.InsertGame(Game{User: "jane.doe", Active: true, Date: storeNow,
+			Challenge: &Challenge{Players: []string{"jane.doe", "john.doe"}, Firsts: []FirstFind{}}})
+
+		if claimed, err := store.ClaimFirst(game.Id, FirstFind{Word: "bat", User: "jane.doe", Date: storeNow}); !claimed || err != nil {
+			t.Errorf("first claim: got %v, %v", claimed, err)
+		}
+
+		if claimed, _ := store.ClaimFirst(game.Id, FirstFind{Word: "bat", User: "john.doe", Date: storeNow}); claimed {
+			t.Error("claimed a word twice")
+		}
+
+		if closed, err := store.CloseChallenge(game.Id, storeNow, "jane.doe"); !closed || err != nil {
+			t.Errorf("close: got %v, %v", closed, err)
+		}
+
+		if closed, _ := store.CloseChallenge(game.Id, storeNow, "john.doe"); closed {
+			t.Error("closed a challenge twice")
+		}
+
+		game, _ = store.GetGame(game.Id)
+		if len(game.Challenge.Firsts) != 1 || game.Challenge.Firsts[0].User != "jane.doe" || game.Challenge.Winner != "jane.doe" {
+			t.Errorf("challenge %+v", game.Challenge)
+		}
+	})
+
+	t.Run("SharedGames", func(t *testing.T) {
+		store := newStore(t)
+		game, _ := store.InsertGame(Game{User: "jane.doe", Active: true, Date: storeNow,
+			Shared: &SharedGame{Channel: "C1", MessageTS: "123.456", Found: []FirstFind{}}})
+
+		store.ClaimShared(game.Id, FirstFind{Word: "bat", User: "jane.doe", Date: storeNow})
+
+		if claimed, _ := store.ClaimShared(game.Id, FirstFind{Word: "bat", User: "john.doe", Date: storeNow}); claimed {
+			t.Error("claimed a word twice")
+		}
+
+		game, _ = store.GetGame(game.Id)
+		if game.Shared.MessageTS != "123.456" || len(game.Shared.Found) != 1 {
+			t.Errorf("shared %+v", game.Shared)
+		}
+
+		solo, _ := store.InsertGame(Game{User: "jane.doe", Active: true, Date: storeNow})
+		if games, _ := store.FindGames(GameQuery{Solo: true}); len(games) != 1 || games[0].Id != solo.Id {
+			t.Errorf("solo games: got %+v", games)
+		}
+	})
+
+	t.Run("CloseDailyGames", func(t *testing.T) {
+		store := newStore(t)
+		daily, _ := store.InsertGame(Game{User: "angrms", Active: true, Daily: true, Date: storeNow})
+		other, _ := store.InsertGame(Game{User: "jane.doe", Active: true, Date: storeNow})
+		today, _ := store.InsertGame(Game{User: "angrms", Active: true, Daily: true, Date: storeNow})
+
+		if err := store.CloseDailyGames(today.Id, storeNow); err != nil {
+			t.Fatal(err)
+		}
+
+		if daily, _ = store.GetGame(daily.Id); daily.Active {
+			t.Error("daily game still active")
+		}
+
+		if other, _ = store.GetGame(other.Id); !other.Active {
+			t.Error("closed a game that wasn't daily")
+		}
+
+		if today, _ = store.GetGame(today.Id); !today.Active {
+			t.Error("closed the new daily game")
+		}
+	})
+
+	t.Run("Progress", func(t *testing.T) {
+		store := newStore(t)
+		game := primitive.NewObjectID()
+		other := primitive.NewObjectID()
+
+		progress, err := store.GetProgress(game, "john.doe")
+		if err != nil || progress.Game != game || progress.User != "john.doe" || len(progress.Words) != 0 {
+			t.Errorf("unstarted: got %+v, %v", progress, err)
+		}
+
+		store.AddFoundWord(game, "john.doe", engine.FoundWord{Word: "bat", Date: storeNow})
+		store.AddFoundWord(game, "john.doe", engine.FoundWord{Word: "bat", Date: storeNow.Add(time.Second)})
+		store.UseHint(game, "john.doe", Hint{Word: "tab", Revealed: 1}, storeNow.Add(time.Minute))
+		store.UseHint(game, "john.doe", Hint{Word: "tab", Revealed: 2}, storeNow.Add(2*time.Minute))
+
+		progress, _ = store.GetProgress(game, "john.doe")
+		if len(progress.Words) != 1 || progress.HintsUsed != 2 || progress.Hint == nil || progress.Hint.Revealed != 2 || !progress.Updated.Equal(storeNow.Add(2*time.Minute)) {
+			t.Errorf("progress %+v", progress)
+		}
+
+		store.AddFoundWord(other, "john.doe", engine.FoundWord{Word: "cat", Date: storeNow.Add(time.Hour)})
+
+		found, _ := store.FindProgress("john.doe", 10)
+		if len(found) != 2 || found[0].Game != other || found[1].Game != game {
+			t.Errorf("find: got %+v", found)
+		}
+
+		if found, _ := store.FindProgress("john.doe", 1); len(found) != 1 {
+			t.Errorf("limit: got %+v", found)
+		}
+
+		store.MarkSolved(game, "john.doe", storeNow)
+		store.MarkRevealed(other, "john.doe", storeNow)
+
+		if found, _ := store.FindProgress("john.doe", 10); len(found) != 0 {
+			t.Errorf("finished games: got %+v", found)
+		}
+	})
+
+	t.Run("StartAttempt", func(t *testing.T) {
+		store := newStore(t)
+		game := primitive.NewObjectID()
+		deadline := storeNow.Add(time.Minute)
+
+		first, err := store.StartAttempt(game, "john.doe", storeNow, deadline)
+		if err != nil || !first.Started.Equal(storeNow) || !first.Deadline.Equal(deadline) {
+			t.Fatalf("start: got %+v, %v", first, err)
+		}
+
+		again, _ := store.StartAttempt(game, "john.doe", storeNow.Add(time.Hour), deadline.Add(time.Hour))
+		if !again.Started.Equal(storeNow) || !again.Deadline.Equal(deadline) {
+			t.Errorf("restarted an attempt: %+v", again)
+		}
+	})
+
+	t.Run("Leaders", func(t *testing.T) {
+		store := newStore(t)
+		lastMonth := storeNow.AddDate(0, -1, 0)
+
+		store.InsertGame(Game{User: "jane.doe", Date: storeNow, Leaderboard: []Leaderboard{
+			{User: "john.doe", Date: storeNow},
+			{User: "joe.doe", Date: storeNow},
+		}})
+		store.InsertGame(Game{User: "jane.doe", Date: storeNow, Leaderboard: []Leaderboard{
+			{User: "john.doe", Date: storeNow},
+		}})
+		store.InsertGame(Game{User: "john.doe", Date: lastMonth, Leaderboard: []Leaderboard{
+			{User: "jane.doe", Date: lastMonth},
+		}})
+
+		if leaders, _ := store.AggregateLeaders(storeNow, "solved", -1); len(leaders) != 0 {
+			t.Errorf("before the rollup: got %+v", leaders)
+		}
+
+		if err := store.RollupLeaders(storeNow); err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			list  string
+			limit int
+			want  []util.GamesStats
+		}{
+			{"solved", -1, []util.GamesStats{{User: "john.doe", Amount: 2}, {User: "joe.doe", Amount: 1}}},
+			{"solved", 1, []util.GamesStats{{User: "john.doe", Amount: 2}}},
+			{"created", -1, []util.GamesStats{{User: "jane.doe", Amount: 2}}},
+			{"usersSolved", -1, []util.GamesStats{{User: "jane.doe", Amount: 2}}},
+		}
+
+		for _, test := range tests {
+			leaders, err := store.AggregateLeaders(storeNow, test.list, test.limit)
+
+			if err != nil || !reflect.DeepEqual(leaders, test.want) {
+				t.Errorf("%s (%d): got %+v, %v", test.list, test.limit, leaders, err)
+			}
+		}
+
+		if leaders, _ := store.AggregateLeaders(lastMonth, "created", -1); len(leaders) != 0 {
+			t.Errorf("last month wasn't rolled up: got %+v", leaders)
+		}
+	})
+}
@@ -0,0 +1,314 @@
+// Command angrms lists, creates and plays Angrms games from a terminal, using
+// the same store and word engine as the Slack app.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/args"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/slices"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var err = godotenv.Load(".env")
+
+const usage = `Usage:
+  angrms list [-all] [-n 20]
+  angrms create -user jane.doe [-team T0123] [-anagram] [-private] [-expires 1d] letters
+  angrms play -user jane.doe game_id
+  angrms leaders game_id
+  angrms dictionary [-collection words] words.json`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	if os.Args[1] == "dictionary" {
+		if err := loadDictionary(os.Args[2:]); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	store := openStore()
+
+	var err error
+	switch os.Args[1] {
+	case "list":
+		err = listGames(store, os.Args[2:])
+	case "create":
+		err = createGame(store, os.Args[2:])
+	case "play":
+		err = playGame(store, os.Args[2:])
+	case "leaders":
+		err = showLeaders(store, os.Args[2:])
+	default:
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// openStore picks the store the same way the Slack app does.
+func openStore() args.Store {
+	if os.Getenv("STORE") == "memory" {
+		return args.NewMemoryStore()
+	}
+
+	return args.NewMongoStore(util.MongoClient().Database("slack"))
+}
+
+// checkUser makes sure user looks like a Slack user name, so the app can
+// split it into a first and last name.
+func checkUser(user string) error {
+	separator := os.Getenv("NAME_SEPARATOR")
+
+	if user == "" || (separator != "" && !strings.Contains(user, separator)) {
+		return errors.New("-user should be a Slack user name, like jane" + separator + "doe")
+	}
+
+	return nil
+}
+
+func findGame(store args.Store, hex string) (args.Game, error) {
+	id, err := primitive.ObjectIDFromHex(hex)
+
+	if err != nil {
+		return args.Game{}, fmt.Errorf("%q isn't a game ID", hex)
+	}
+
+	game, err := store.GetGame(id)
+
+	if err == args.ErrNotFound {
+		return game, fmt.Errorf("no game with ID %s", hex)
+	}
+
+	return game, err
+}
+
+func listGames(store args.Store, arguments []string) error {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	all := flags.Bool("all", false, "include games that have ended")
+	limit := flags.Int64("n", 20, "how many games to list")
+	flags.Parse(arguments)
+
+	games, err := store.FindGames(args.GameQuery{Active: !*all, Sort: args.SortNewest, Limit: *limit})
+
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, game := range games {
+		var notes []string
+		if !game.Puzzle().Playable(now) {
+			notes = append(notes, "ended")
+		}
+		if game.Private {
+			notes = append(notes, "private")
+		}
+		if game.Daily {
+			notes = append(notes, "daily")
+		}
+		if game.Challenge != nil {
+			notes = append(notes, "challenge")
+		}
+		if game.Shared != nil {
+			notes = append(notes, "team")
+		}
+		if game.Mode == engine.ModeAnagram {
+			notes = append(notes, "anagram")
+		}
+		if !game.ExpiresAt.IsZero() && game.Puzzle().Playable(now) {
+			notes = append(notes, "until "+game.ExpiresAt.Local().Format("Jan 2 3:04 PM"))
+		}
+
+		line := game.Id.Hex() + "  " + strings.ToUpper(game.Letters) + "  " + game.User + "  " + strconv.Itoa(len(game.Words)) + " words, " + strconv.Itoa(len(game.Leaderboard)) + " solved"
+		if len(notes) > 0 {
+			line += "  (" + strings.Join(notes, ", ") + ")"
+		}
+
+		fmt.Println(line)
+	}
+
+	if len(games) == 0 {
+		fmt.Println("No games found")
+	}
+
+	return nil
+}
+
+func createGame(store args.Store, arguments []string) error {
+	flags := flag.NewFlagSet("create", flag.ExitOnError)
+	user := flags.String("user", "", "Slack user name to create the game as")
+	team := flags.String("team", "", "Slack team ID, to use that team's dictionary")
+	anagram := flags.Bool("anagram", false, "only allow each letter as many times as it is given")
+	private := flags.Bool("private", false, "only let the creator play")
+	expires := flags.String("expires", "", "how long the game lasts, like 30m, 1d12h or friday 5pm")
+	flags.Parse(arguments)
+
+	if err := checkUser(*user); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New("give the game's letters, like: angrms create -user jane.doe tabe")
+	}
+
+	if err := slices.LoadDictionaries(os.Getenv("DICTIONARY"), os.Getenv("TEAM_DICTIONARIES")); err != nil {
+		return err
+	}
+
+	dictionary, err := slices.ForTeam(*team)
+
+	if err != nil {
+		return err
+	}
+
+	mode := engine.ModeReuse
+	if *anagram {
+		mode = engine.ModeAnagram
+	}
+
+	now := time.Now()
+	created, err := engine.CreateGame(dictionary, engine.CreateOptions{
+		Letters:    flags.Arg(0),
+		Mode:       mode,
+		Expiration: *expires,
+	}, now)
+
+	if err != nil {
+		return err
+	}
+
+	game, err := store.InsertGame(args.Game{
+		User:        *user,
+		Active:      true,
+		Date:        now,
+		Words:       created.Words,
+		Leaderboard: make([]args.Leaderboard, 0),
+		Letters:     created.Letters,
+		Private:     *private,
+		Expiration:  *expires,
+		Team:        *team,
+		Rules:       created.Rules,
+		Mode:        created.Mode,
+		ExpiresAt:   created.ExpiresAt,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Created " + game.Id.Hex() + ": " + strings.ToUpper(game.Letters) + " with " + strconv.Itoa(len(game.Words)) + " words to find")
+	return nil
+}
+
+func playGame(store args.Store, arguments []string) error {
+	flags := flag.NewFlagSet("play", flag.ExitOnError)
+	user := flags.String("user", "", "Slack user name to play as")
+	flags.Parse(arguments)
+
+	if err := checkUser(*user); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New("give the ID of the game to play")
+	}
+
+	game, err := findGame(store, flags.Arg(0))
+
+	if err != nil {
+		return err
+	}
+
+	return play(store, game, *user, os.Stdin, os.Stdout)
+}
+
+func showLeaders(store args.Store, arguments []string) error {
+	if len(arguments) != 1 {
+		return errors.New("give the ID of the game")
+	}
+
+	game, err := findGame(store, arguments[0])
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(strings.ToUpper(game.Letters) + " by " + game.User + " - " + strconv.Itoa(len(game.Words)) + " words")
+
+	if len(game.Leaderboard) == 0 {
+		fmt.Println("Nobody has solved it yet")
+	}
+
+	for i, leader := range game.Leaderboard {
+		line := strconv.Itoa(i+1) + ") " + leader.User + "  " + leader.Date.Local().Format("_2 Jan 2006 3:04:05 PM")
+		if leader.Assisted {
+			line += "  (hints)"
+		}
+
+		fmt.Println(line)
+	}
+
+	if len(game.Scores) == 0 {
+		return nil
+	}
+
+	scores := append([]args.Score(nil), game.Scores...)
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Points > scores[j].Points
+	})
+
+	fmt.Println("\nScores")
+	for _, score := range scores {
+		fmt.Println("  " + score.User + "  " + strconv.Itoa(score.Points) + " points, " + strconv.Itoa(score.Words) + " words")
+	}
+
+	return nil
+}
+
+// loadDictionary fills a Mongo collection for a DICTIONARY=mongo:<collection>
+// source, replacing any words already in it.
+func loadDictionary(arguments []string) error {
+	flags := flag.NewFlagSet("dictionary", flag.ExitOnError)
+	collection := flags.String("collection", "words", "collection to load the words into")
+	flags.Parse(arguments)
+
+	if flags.NArg() != 1 {
+		return errors.New("give a words.json style file or a file with one word per line")
+	}
+
+	words, err := slices.ReadWords(flags.Arg(0))
+
+	if err != nil {
+		return err
+	}
+
+	dictionary := slices.NewMongoDictionary(util.MongoClient().Database("slack").Collection(*collection))
+
+	if err := dictionary.Load(words); err != nil {
+		return err
+	}
+
+	fmt.Println("Loaded " + strconv.Itoa(len(words)) + " words into " + *collection + ".  Use DICTIONARY=mongo:" + *collection + " to play with them.")
+	return nil
+}
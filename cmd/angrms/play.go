@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.sweetwater.com/mike_mayo/slackbot/args"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/engine"
+)
+
+// play reads guesses from in until the game is solved, ends or in runs out,
+// saving progress the same way the play modal does.
+func play(store args.Store, game args.Game, user string, in io.Reader, out io.Writer) error {
+	if game.Challenge != nil || game.Shared != nil || game.Sprint > 0 {
+		return errors.New("challenge, team and sprint games can only be played in Slack")
+	}
+
+	if game.Private && game.User != user {
+		return errors.New("this game is private")
+	}
+
+	progress, err := store.GetProgress(game.Id, user)
+
+	if err != nil {
+		return err
+	}
+
+	if progress.Revealed {
+		return errors.New("you've already revealed the answers to this game")
+	}
+
+	puzzle := game.Puzzle()
+
+	var found []string
+	for _, word := range progress.Words {
+		found = append(found, word.Word)
+	}
+
+	status := func() string {
+		result := engine.Progress(puzzle, progress.Words, progress.HintsUsed)
+		return strconv.Itoa(result.Remaining) + " words left!  Score: " + strconv.Itoa(result.Points)
+	}
+
+	fmt.Fprintln(out, strings.ToUpper(game.Letters)+" - "+status())
+	if len(found) > 0 {
+		fmt.Fprintln(out, "You found: "+strings.Join(found, ", "))
+	}
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		guess := strings.TrimSpace(scanner.Text())
+
+		if guess == "" {
+			continue
+		}
+
+		now := time.Now()
+		result := engine.Guess(puzzle, found, guess, now)
+
+		switch result.Outcome {
+		case engine.Closed:
+			fmt.Fprintln(out, "This game is over.")
+			return nil
+		case engine.AlreadyGuessed:
+			fmt.Fprintln(out, strings.ToUpper(result.Guess)+" already guessed!")
+			continue
+		case engine.Incorrect:
+			fmt.Fprintln(out, strings.ToUpper(result.Guess)+" is incorrect!")
+			continue
+		}
+
+		progress.Words = append(progress.Words, result.Found)
+		found = append(found, result.Found.Word)
+
+		if err := store.AddFoundWord(game.Id, user, result.Found); err != nil {
+			return err
+		}
+
+		err := store.RecordScore(game.Id, args.Score{
+			User:   user,
+			Points: engine.Progress(puzzle, progress.Words, progress.HintsUsed).Points,
+			Words:  len(progress.Words),
+			Date:   now,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(out, strings.ToUpper(result.Found.Word)+"!  "+status())
+
+		if result.Solved {
+			if err := store.MarkSolved(game.Id, user, now); err != nil {
+				return err
+			}
+
+			err := store.AddLeader(game.Id, args.Leaderboard{User: user, Date: now, Assisted: progress.HintsUsed > 0})
+
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(out, "Solved!! You found all the words and are on the leaderboard.")
+			return nil
+		}
+	}
+
+	return scanner.Err()
+}
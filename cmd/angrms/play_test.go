@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gitlab.sweetwater.com/mike_mayo/slackbot/args"
+)
+
+func TestPlay(t *testing.T) {
+	store := args.NewMemoryStore()
+	game, _ := store.InsertGame(args.Game{User: "jane.doe", Letters: "tab", Words: []string{"bat", "tab"}, Active: true})
+
+	var out bytes.Buffer
+	if err := play(store, game, "john.doe", strings.NewReader("bat\nBAT\nboat\n\ntab\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"BAT!  1 words left", "BAT already guessed!", "BOAT is incorrect!", "Solved!!"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, out.String())
+		}
+	}
+
+	game, _ = store.GetGame(game.Id)
+	if len(game.Leaderboard) != 1 || game.Leaderboard[0].User != "john.doe" {
+		t.Errorf("leaderboard %+v", game.Leaderboard)
+	}
+
+	if len(game.Scores) != 1 || game.Scores[0].Words != 2 {
+		t.Errorf("scores %+v", game.Scores)
+	}
+}
+
+func TestPlayPicksUpProgress(t *testing.T) {
+	store := args.NewMemoryStore()
+	game, _ := store.InsertGame(args.Game{User: "jane.doe", Letters: "tab", Words: []string{"bat", "tab"}, Active: true})
+
+	play(store, game, "john.doe", strings.NewReader("bat\n"), &bytes.Buffer{})
+
+	var out bytes.Buffer
+	play(store, game, "john.doe", strings.NewReader("bat\n"), &out)
+
+	if !strings.Contains(out.String(), "You found: bat") || !strings.Contains(out.String(), "BAT already guessed!") {
+		t.Errorf("got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,135 @@
+package engine
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gitlab.sweetwater.com/mike_mayo/slackbot/slices"
+)
+
+var (
+	ErrNoLetters       = errors.New("choose some letters, or a number of letters to be picked at random")
+	ErrCenterLetter    = errors.New("the required letter isn't one of the game's letters")
+	ErrNoWords         = errors.New("no words can be made with those letters")
+	ErrNoRandomLetters = errors.New("couldn't pick letters that make the right number of words")
+	ErrTooManyLetters  = slices.ErrTooManyLetters
+)
+
+// CreateOptions describes a new game. Either Letters or Random, the number
+// of letters to pick at random, is set. Random games aim for between
+// MinWords and MaxWords words. Expiration is read relative to now, in now's
+// timezone.
+type CreateOptions struct {
+	Letters    string
+	Random     int
+	Mode       string
+	Rules      Rules
+	Expiration string
+	MinWords   int
+	MaxWords   int
+}
+
+// FindWords builds the word list for a game. Without a required letter
+// every word has to use all of the letters; with one, words may use any of
+// them as long as the required letter is there. Anagram games take any word
+// that fits in the letters given. Those last two search every subset of the
+// letters, so they can use at most slices.MaxLetters different letters.
+func FindWords(dictionary slices.Dictionary, letters string, mode string, rules Rules) ([]string, error) {
+	all := rules.CenterLetter == "" && mode != ModeAnagram
+
+	if !all && len(removeDuplicates(letters)) > slices.MaxLetters {
+		return nil, ErrTooManyLetters
+	}
+
+	candidates, err := dictionary.Find(letters, all)
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts := slices.LetterCounts(letters)
+
+	var words []string
+	for _, word := range candidates {
+		if mode == ModeAnagram && !counts.Spells(word) {
+			continue
+		}
+
+		if rules.Allows(word) {
+			words = append(words, word)
+		}
+	}
+
+	return words, nil
+}
+
+// RandomLetters picks n letters whose word list, with the given mode and
+// rules, has between minWords and maxWords words.
+func RandomLetters(dictionary slices.Dictionary, n int, mode string, rules Rules, minWords int, maxWords int) (string, []string, error) {
+	var words []string
+	letters, err := slices.RandomLetters(dictionary, n, func(letters string) (bool, error) {
+		if rules.CenterLetter != "" && !strings.Contains(letters, rules.CenterLetter) {
+			return false, nil
+		}
+
+		found, err := FindWords(dictionary, letters, mode, rules)
+		words = found
+
+		return len(found) >= minWords && len(found) <= maxWords, err
+	})
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return letters, words, nil
+}
+
+// CreateGame builds a new, active game from options.
+func CreateGame(dictionary slices.Dictionary, options CreateOptions, now time.Time) (Game, error) {
+	game := Game{Mode: options.Mode, Rules: options.Rules, Active: true}
+
+	expiresAt, err := ParseExpiry(options.Expiration, now)
+
+	if err != nil {
+		return game, err
+	}
+
+	game.ExpiresAt = expiresAt
+
+	if options.Random > 0 {
+		letters, words, err := RandomLetters(dictionary, options.Random, options.Mode, options.Rules, options.MinWords, options.MaxWords)
+
+		if err != nil {
+			return game, ErrNoRandomLetters
+		}
+
+		game.Letters = letters
+		game.Words = words
+		return game, nil
+	}
+
+	game.Letters = Letters(options.Letters, options.Mode)
+
+	if strings.TrimSpace(game.Letters) == "" {
+		return game, ErrNoLetters
+	}
+
+	if center := options.Rules.CenterLetter; center != "" && !strings.Contains(game.Letters, center) {
+		return game, ErrCenterLetter
+	}
+
+	words, err := FindWords(dictionary, game.Letters, options.Mode, options.Rules)
+
+	if err != nil {
+		return game, err
+	}
+
+	if len(words) == 0 {
+		return game, ErrNoWords
+	}
+
+	game.Words = words
+	return game, nil
+}
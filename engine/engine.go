@@ -0,0 +1,96 @@
+// Package engine holds the rules of Angrms: building a game's word list,
+// checking guesses, scoring and expiry. It knows nothing about Slack or
+// where games are stored, so any front end can drive it.
+package engine
+
+import (
+	"strings"
+	"time"
+)
+
+// Game modes. In the default mode letters can be reused any number of times;
+// in anagram mode each letter can be used only as often as it was given.
+const (
+	ModeReuse   = ""
+	ModeAnagram = "anagram"
+)
+
+type Rules struct {
+	MinLength    int    `bson:"minLength,omitempty"`
+	MaxLength    int    `bson:"maxLength,omitempty"`
+	CenterLetter string `bson:"centerLetter,omitempty"`
+}
+
+// Game is everything the rules need to know about a game.
+type Game struct {
+	Letters   string
+	Words     []string
+	Mode      string
+	Rules     Rules
+	Active    bool
+	ExpiresAt time.Time
+}
+
+type FoundWord struct {
+	Word string    `bson:"word"`
+	Date time.Time `bson:"date"`
+}
+
+func (rules Rules) Allows(word string) bool {
+	if rules.MinLength > 0 && len(word) < rules.MinLength {
+		return false
+	}
+
+	if rules.MaxLength > 0 && len(word) > rules.MaxLength {
+		return false
+	}
+
+	if rules.CenterLetter != "" && !strings.Contains(word, rules.CenterLetter) {
+		return false
+	}
+
+	return true
+}
+
+// Letters cleans up the letters a game is created with. Anagram games keep
+// repeated letters since they limit how often each can be used.
+func Letters(letters string, mode string) string {
+	letters = strings.ToLower(letters)
+
+	if mode != ModeAnagram {
+		return removeDuplicates(letters)
+	}
+
+	var kept strings.Builder
+	for _, letter := range letters {
+		if letter >= 'a' && letter <= 'z' {
+			kept.WriteRune(letter)
+		}
+	}
+
+	return kept.String()
+}
+
+func removeDuplicates(letters string) string {
+	stringSet := make(map[string]bool, 0)
+	letterSet := ""
+
+	for _, letter := range strings.Split(letters, "") {
+		if !stringSet[letter] {
+			stringSet[letter] = true
+			letterSet += letter
+		}
+	}
+
+	return letterSet
+}
+
+func contains(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+
+	return false
+}
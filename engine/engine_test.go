@@ -0,0 +1,191 @@
+package engine
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"gitlab.sweetwater.com/mike_mayo/slackbot/slices"
+)
+
+var testDictionary = slices.NewIndex([]string{"bat", "tab", "abbot", "boat", "tea", "eat", "ate", "beat", "abate"})
+
+var now = time.Date(2023, time.January, 11, 14, 30, 0, 0, time.UTC)
+
+func TestCreateGame(t *testing.T) {
+	game, err := CreateGame(testDictionary, CreateOptions{Letters: "TaBt"}, now)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(game.Words)
+	if game.Letters != "tab" || !reflect.DeepEqual(game.Words, []string{"bat", "tab"}) || !game.Active {
+		t.Errorf("got %+v", game)
+	}
+
+	game, err = CreateGame(testDictionary, CreateOptions{Letters: "abte", Rules: Rules{CenterLetter: "e", MinLength: 4}, Expiration: "1d"}, now)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(game.Words)
+	if !reflect.DeepEqual(game.Words, []string{"abate", "beat"}) {
+		t.Errorf("got %v", game.Words)
+	}
+
+	if !game.ExpiresAt.Equal(now.AddDate(0, 0, 1)) {
+		t.Errorf("expires %v", game.ExpiresAt)
+	}
+}
+
+func TestCreateGameErrors(t *testing.T) {
+	tests := []struct {
+		options CreateOptions
+		want    error
+	}{
+		{CreateOptions{}, ErrNoLetters},
+		{CreateOptions{Letters: "xyz"}, ErrNoWords},
+		{CreateOptions{Letters: "abt", Rules: Rules{CenterLetter: "e"}}, ErrCenterLetter},
+		{CreateOptions{Letters: "abt", Expiration: "soon"}, ErrExpiry},
+		{CreateOptions{Letters: "abcdefghijklmt", Mode: ModeAnagram}, ErrTooManyLetters},
+		{CreateOptions{Letters: "abcdefghijklmt", Rules: Rules{CenterLetter: "a"}}, ErrTooManyLetters},
+		{CreateOptions{Random: 3, MinWords: 50, MaxWords: 60}, ErrNoRandomLetters},
+	}
+
+	for _, test := range tests {
+		if _, err := CreateGame(testDictionary, test.options, now); err != test.want {
+			t.Errorf("%+v: got %v, want %v", test.options, err, test.want)
+		}
+	}
+}
+
+func TestAnagramMode(t *testing.T) {
+	game, err := CreateGame(testDictionary, CreateOptions{Letters: "abot", Mode: ModeAnagram}, now)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(game.Words)
+	if !reflect.DeepEqual(game.Words, []string{"bat", "boat", "tab"}) {
+		t.Errorf("got %v", game.Words)
+	}
+}
+
+func TestGuess(t *testing.T) {
+	game := Game{Letters: "tab", Words: []string{"bat", "tab"}, Active: true}
+
+	tests := []struct {
+		found   []string
+		guess   string
+		outcome Outcome
+		solved  bool
+	}{
+		{nil, "BAT", Correct, false},
+		{[]string{"bat"}, "tab", Correct, true},
+		{[]string{"bat"}, "bat", AlreadyGuessed, false},
+		{nil, "tabs", Incorrect, false},
+	}
+
+	for _, test := range tests {
+		result := Guess(game, test.found, test.guess, now)
+
+		if result.Outcome != test.outcome || result.Solved != test.solved {
+			t.Errorf("%q: got %+v", test.guess, result)
+		}
+	}
+
+	game.ExpiresAt = now
+	if result := Guess(game, nil, "bat", now); result.Outcome != Closed {
+		t.Errorf("expired game: got %+v", result)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	game := Game{Letters: "abte", Words: []string{"bat", "beat", "abate"}, Rules: Rules{CenterLetter: "b"}, Active: true}
+	words := []FoundWord{
+		{Word: "bat", Date: now},
+		// A pangram found within the speed window.
+		{Word: "beat", Date: now.Add(10 * time.Second)},
+	}
+
+	result := Progress(game, words, 0)
+	want := ProgressResult{Found: 2, Remaining: 1, Points: 1 + 4 + PangramBonus + SpeedBonus}
+
+	if result != want {
+		t.Errorf("got %+v, want %+v", result, want)
+	}
+
+	if result := Progress(game, words, 10); result.Points != 0 {
+		t.Errorf("hints took points below zero: %+v", result)
+	}
+
+	// Every word uses every letter without a required letter, so none of
+	// them earn the bonus.
+	game.Rules = Rules{}
+	if result := Progress(game, words, 0); result.Points != 1+4+SpeedBonus {
+		t.Errorf("no required letter: got %+v", result)
+	}
+
+	game.Mode = ModeAnagram
+	if result := Progress(game, words, 0); result.Points != 1+4+PangramBonus+SpeedBonus {
+		t.Errorf("anagram: got %+v", result)
+	}
+}
+
+func TestSolveAndExpire(t *testing.T) {
+	game := Game{Words: []string{"bat", "tab"}, Active: true, ExpiresAt: now.Add(time.Hour)}
+
+	if result := Solve(game, []string{"tab"}); result.Solved || !reflect.DeepEqual(result.Missing, []string{"bat"}) {
+		t.Errorf("got %+v", result)
+	}
+
+	if result := Solve(game, []string{"tab", "bat"}); !result.Solved {
+		t.Errorf("got %+v", result)
+	}
+
+	if result := Expire(game, now); result.Expired {
+		t.Errorf("expired early: %+v", result)
+	}
+
+	later := now.Add(2 * time.Hour)
+	if result := Expire(game, later); !result.Expired || !result.ClosedAt.Equal(later) {
+		t.Errorf("got %+v", result)
+	}
+}
+
+func TestRandomGamesHaveDefinitions(t *testing.T) {
+	dictionary, err := slices.OpenSource("../words.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lexicon, err := slices.LoadLexicon("../definitions.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for n := 4; n <= 9; n++ {
+		game, err := CreateGame(dictionary, CreateOptions{Random: n, Mode: ModeReuse, MinWords: 15, MaxWords: 60}, now)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var missing []string
+		for _, word := range game.Words {
+			if _, ok := lexicon.Define(word); !ok {
+				missing = append(missing, word)
+			}
+		}
+
+		if len(missing)*10 > len(game.Words) {
+			t.Errorf("%s: %d of %d words have no definition: %v", game.Letters, len(missing), len(game.Words), missing)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package engine
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationPart = regexp.MustCompile(`^(\d+)\s*([a-z]+)\s*`)
+var clockTime = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+
+var durationUnits = map[string]time.Duration{
+	"m":       time.Minute,
+	"min":     time.Minute,
+	"mins":    time.Minute,
+	"minute":  time.Minute,
+	"minutes": time.Minute,
+	"h":       time.Hour,
+	"hr":      time.Hour,
+	"hrs":     time.Hour,
+	"hour":    time.Hour,
+	"hours":   time.Hour,
+}
+
+var dayUnits = map[string]int{
+	"d":     1,
+	"day":   1,
+	"days":  1,
+	"w":     7,
+	"wk":    7,
+	"wks":   7,
+	"week":  7,
+	"weeks": 7,
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var ErrExpiry = errors.New("expiration should be a duration like 30m, 1d12h or 2w, or a time like end of day or friday 5pm")
+
+// ParseExpiry turns a game expiration into the time the game ends. It
+// accepts compound durations ("1d12h", "2 weeks"), "end of day", "end of
+// week", and a day and/or time of day ("friday 5pm", "tomorrow", "17:30").
+// Days and times are read in now's location. A blank expiration returns the
+// zero time, meaning the game never ends.
+func ParseExpiry(input string, now time.Time) (time.Time, error) {
+	input = strings.Join(strings.Fields(strings.ToLower(input)), " ")
+
+	switch input {
+	case "":
+		return time.Time{}, nil
+	case "end of day", "eod", "tonight":
+		return endOfDay(now), nil
+	case "end of week", "eow":
+		return endOfDay(now.AddDate(0, 0, (7-int(now.Weekday()))%7)), nil
+	}
+
+	if expires, ok := parseDuration(input, now); ok {
+		return expires, nil
+	}
+
+	if expires, ok := parseDayAndTime(input, now); ok {
+		return expires, nil
+	}
+
+	return time.Time{}, ErrExpiry
+}
+
+func endOfDay(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 59, 0, day.Location())
+}
+
+func parseDuration(input string, now time.Time) (time.Time, bool) {
+	expires := now
+
+	for input != "" {
+		match := durationPart.FindStringSubmatch(input)
+
+		if match == nil {
+			return time.Time{}, false
+		}
+
+		amount, err := strconv.Atoi(match[1])
+
+		if err != nil || amount < 1 {
+			return time.Time{}, false
+		}
+
+		if unit, ok := durationUnits[match[2]]; ok {
+			expires = expires.Add(time.Duration(amount) * unit)
+		} else if days, ok := dayUnits[match[2]]; ok {
+			expires = expires.AddDate(0, 0, amount*days)
+		} else {
+			return time.Time{}, false
+		}
+
+		input = input[len(match[0]):]
+	}
+
+	return expires, true
+}
+
+func parseClock(input string) (int, int, bool) {
+	match := clockTime.FindStringSubmatch(input)
+
+	if match == nil {
+		return 0, 0, false
+	}
+
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+
+	switch match[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+
+		hour %= 12
+		if match[3] == "pm" {
+			hour += 12
+		}
+	default:
+		if match[2] == "" {
+			return 0, 0, false
+		}
+	}
+
+	if hour > 23 || minute > 59 {
+		return 0, 0, false
+	}
+
+	return hour, minute, true
+}
+
+// parseDayAndTime handles "friday", "friday 5pm", "tomorrow 9:30am", "today
+// 17:00" and a bare "5pm". A day without a time means the end of that day; a
+// time without a day means its next occurrence.
+func parseDayAndTime(input string, now time.Time) (time.Time, bool) {
+	dayWord, clock, _ := strings.Cut(input, " ")
+	offset := -1
+
+	switch dayWord {
+	case "today":
+		offset = 0
+	case "tomorrow":
+		offset = 1
+	default:
+		if weekday, ok := weekdays[dayWord]; ok {
+			offset = (int(weekday) - int(now.Weekday()) + 7) % 7
+		} else {
+			clock = input
+		}
+	}
+
+	if offset == -1 {
+		hour, minute, ok := parseClock(clock)
+
+		if !ok {
+			return time.Time{}, false
+		}
+
+		expires := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+		if !expires.After(now) {
+			expires = expires.AddDate(0, 0, 1)
+		}
+
+		return expires, true
+	}
+
+	day := now.AddDate(0, 0, offset)
+
+	if clock == "" {
+		return endOfDay(day), true
+	}
+
+	hour, minute, ok := parseClock(clock)
+
+	if !ok {
+		return time.Time{}, false
+	}
+
+	expires := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location())
+
+	if !expires.After(now) {
+		if dayWord == "today" || dayWord == "tomorrow" {
+			return time.Time{}, false
+		}
+
+		expires = expires.AddDate(0, 0, 7)
+	}
+
+	return expires, true
+}
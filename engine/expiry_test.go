@@ -0,0 +1,56 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseExpiry(t *testing.T) {
+	location := time.FixedZone("EST", -5*60*60)
+	// A Wednesday afternoon.
+	now := time.Date(2023, time.January, 11, 14, 30, 0, 0, location)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"", time.Time{}},
+		{"30m", now.Add(30 * time.Minute)},
+		{"3d", now.AddDate(0, 0, 3)},
+		{"1d12h", now.AddDate(0, 0, 1).Add(12 * time.Hour)},
+		{"1d 12h 5m", now.AddDate(0, 0, 1).Add(12*time.Hour + 5*time.Minute)},
+		{"2 weeks", now.AddDate(0, 0, 14)},
+		{"1w", now.AddDate(0, 0, 7)},
+		{"End of Day", time.Date(2023, time.January, 11, 23, 59, 59, 0, location)},
+		{"end of week", time.Date(2023, time.January, 15, 23, 59, 59, 0, location)},
+		{"friday 5pm", time.Date(2023, time.January, 13, 17, 0, 0, 0, location)},
+		{"fri", time.Date(2023, time.January, 13, 23, 59, 59, 0, location)},
+		{"wednesday 9am", time.Date(2023, time.January, 18, 9, 0, 0, 0, location)},
+		{"tomorrow 9:30am", time.Date(2023, time.January, 12, 9, 30, 0, 0, location)},
+		{"5pm", time.Date(2023, time.January, 11, 17, 0, 0, 0, location)},
+		{"13:15", time.Date(2023, time.January, 12, 13, 15, 0, 0, location)},
+	}
+
+	for _, test := range tests {
+		got, err := ParseExpiry(test.input, now)
+
+		if err != nil {
+			t.Errorf("%q: %v", test.input, err)
+			continue
+		}
+
+		if !got.Equal(test.want) {
+			t.Errorf("%q: got %v, want %v", test.input, got, test.want)
+		}
+	}
+}
+
+func TestParseExpiryRejects(t *testing.T) {
+	now := time.Date(2023, time.January, 11, 14, 30, 0, 0, time.UTC)
+
+	for _, input := range []string{"3", "d", "0d", "3y", "soon", "today 9am", "friday 25pm", "13pm", "1d banana"} {
+		if _, err := ParseExpiry(input, now); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
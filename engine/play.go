@@ -0,0 +1,183 @@
+package engine
+
+import (
+	"strings"
+	"time"
+
+	"gitlab.sweetwater.com/mike_mayo/slackbot/slices"
+)
+
+// Points: words of up to ShortWordLength letters are worth one point, longer
+// words a point per letter. In games with a required letter, and anagram
+// games, a pangram (a word that uses every one of the game's letters) earns
+// PangramBonus on top. Other games only take words that use every letter,
+// so there is no bonus to give. A word found within SpeedWindow of the one
+// before it earns SpeedBonus. Every hint costs HintCost.
+const (
+	ShortWordLength = 3
+	PangramBonus    = 7
+	SpeedBonus      = 2
+	SpeedWindow     = 30 * time.Second
+	HintCost        = 3
+)
+
+type Outcome int
+
+const (
+	Correct Outcome = iota
+	Incorrect
+	AlreadyGuessed
+	Closed
+)
+
+type GuessResult struct {
+	Outcome Outcome
+	Guess   string
+	// Found is set when the guess was correct.
+	Found     FoundWord
+	Points    int
+	Remaining int
+	Solved    bool
+}
+
+type ProgressResult struct {
+	Found     int
+	Remaining int
+	Points    int
+	Solved    bool
+}
+
+type SolveResult struct {
+	Solved  bool
+	Missing []string
+}
+
+type ExpireResult struct {
+	Expired  bool
+	ClosedAt time.Time
+}
+
+func (game Game) Expired(now time.Time) bool {
+	return !game.ExpiresAt.IsZero() && !now.Before(game.ExpiresAt)
+}
+
+func (game Game) Playable(now time.Time) bool {
+	return game.Active && !game.Expired(now)
+}
+
+// Guess checks a guess against a game, given the words already found.
+func Guess(game Game, found []string, guess string, now time.Time) GuessResult {
+	guess = strings.ToLower(strings.TrimSpace(guess))
+	result := GuessResult{Guess: guess, Remaining: len(game.Words) - len(found)}
+
+	switch {
+	case !game.Playable(now):
+		result.Outcome = Closed
+	case contains(found, guess):
+		result.Outcome = AlreadyGuessed
+	case !contains(game.Words, guess):
+		result.Outcome = Incorrect
+	default:
+		result.Outcome = Correct
+		result.Found = FoundWord{Word: guess, Date: now}
+		result.Points = WordPoints(game, guess)
+		result.Remaining--
+		result.Solved = result.Remaining == 0
+	}
+
+	return result
+}
+
+// Progress sums up a player's found words, in the order they found them,
+// after paying for hintsUsed hints. Points never go below zero.
+func Progress(game Game, words []FoundWord, hintsUsed int) ProgressResult {
+	var found []string
+	for _, word := range words {
+		found = append(found, word.Word)
+	}
+
+	points := ScoreWords(game, words) - hintsUsed*HintCost
+	if points < 0 {
+		points = 0
+	}
+
+	return ProgressResult{
+		Found:     len(words),
+		Remaining: len(game.Words) - len(words),
+		Points:    points,
+		Solved:    Solve(game, found).Solved,
+	}
+}
+
+// Solve reports whether found covers every word in the game, and which
+// words are still missing.
+func Solve(game Game, found []string) SolveResult {
+	var missing []string
+	for _, word := range game.Words {
+		if !contains(found, word) {
+			missing = append(missing, word)
+		}
+	}
+
+	return SolveResult{Solved: len(missing) == 0, Missing: missing}
+}
+
+// Expire reports whether a game should be closed at now.
+func Expire(game Game, now time.Time) ExpireResult {
+	if !game.Active || !game.Expired(now) {
+		return ExpireResult{}
+	}
+
+	return ExpireResult{Expired: true, ClosedAt: now}
+}
+
+// IsPangram reports whether word uses every one of the game's letters in a
+// game where that isn't already required of every word.
+func IsPangram(game Game, word string) bool {
+	if game.Rules.CenterLetter == "" && game.Mode != ModeAnagram {
+		return false
+	}
+
+	letters := slices.LetterCounts(game.Letters)
+	used := slices.LetterCounts(word)
+
+	for i := range letters {
+		if letters[i] > 0 && used[i] == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func WordPoints(game Game, word string) int {
+	points := 1
+	if len(word) > ShortWordLength {
+		points = len(word)
+	}
+
+	if IsPangram(game, word) {
+		points += PangramBonus
+	}
+
+	return points
+}
+
+// ScoreWords adds up the points for the words a player found, in the order
+// they found them.
+func ScoreWords(game Game, words []FoundWord) int {
+	total := 0
+	var previous time.Time
+
+	for _, found := range words {
+		total += WordPoints(game, found.Word)
+
+		if !previous.IsZero() && found.Date.Sub(previous) <= SpeedWindow {
+			total += SpeedBonus
+		}
+
+		previous = found.Date
+	}
+
+	return total
+}
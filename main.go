@@ -7,14 +7,62 @@ import (
 	"os"
 
 	"github.com/joho/godotenv"
+	"github.com/slack-go/slack"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/args"
 	"gitlab.sweetwater.com/mike_mayo/slackbot/slackHandler"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/slices"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/util"
 )
 
 var err = godotenv.Load(".env")
 
 func main() {
-	http.HandleFunc("/", slackHandler.SlashCommandHandler)
-	http.HandleFunc("/interactive", slackHandler.InteractiveHandler)
+	err := slices.LoadDictionaries(os.Getenv("DICTIONARY"), os.Getenv("TEAM_DICTIONARIES"))
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	definitionsPath := os.Getenv("DEFINITIONS")
+	if definitionsPath == "" {
+		definitionsPath = "definitions.json"
+	}
+
+	lexicon, err := slices.LoadLexicon(definitionsPath)
+
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		slices.SetDefiner(lexicon)
+	}
+
+	api := slack.New(
+		os.Getenv("OAUTH_TOKEN"),
+		slack.OptionDebug(os.Getenv("SLACK_DEBUG") == "true"),
+		slack.OptionAppLevelToken(os.Getenv("APP_TOKEN")),
+	)
+
+	var store args.Store
+	if os.Getenv("STORE") == "memory" {
+		store = args.NewMemoryStore()
+	} else {
+		store = args.NewMongoStore(util.MongoClient().Database("slack"))
+	}
+
+	app := args.New(store, api)
+	handler := slackHandler.New(app, os.Getenv("SIGNING_SECRET"))
+
+	go app.RunDailyScheduler()
+	go app.RunExpirySweeper()
+	go app.RunLeadersRollup()
+
+	if os.Getenv("TRANSPORT") == "socket" {
+		log.Fatal(handler.RunSocketMode(api))
+	}
+
+	http.HandleFunc("/", handler.SlashCommandHandler)
+	http.HandleFunc("/interactive", handler.InteractiveHandler)
+	http.HandleFunc("/events", handler.EventsHandler)
 
 	port := os.Getenv("PORT")
 	fmt.Println("Just felt like running.... http://localhost" + port)
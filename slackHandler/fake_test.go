@@ -0,0 +1,222 @@
+package slackHandler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const testSigningSecret = "test-signing-secret"
+
+// slackCall is one request the app made to the fake Slack API.
+type slackCall struct {
+	Method    string
+	View      slack.View
+	Channel   string
+	Timestamp string
+	Text      string
+	// Blocks is the message's blocks as Slack received them, in JSON.
+	Blocks string
+}
+
+// notMemberChannel is a channel the app can't post in.
+const notMemberChannel = "C404"
+
+// fakeUsers are the workspace members the fake knows, by ID.
+var fakeUsers = map[string]string{
+	"U1": testUser,
+	"U2": "john.doe",
+}
+
+// fakeSlack stands in for the Slack Web API methods Angrms calls and records
+// every call so tests can check what would have been shown or sent.
+type fakeSlack struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	calls []slackCall
+	views int
+}
+
+func newFakeSlack(t *testing.T) *fakeSlack {
+	fake := &fakeSlack{}
+	fake.Server = httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(fake.Close)
+
+	return fake
+}
+
+func (fake *fakeSlack) serve(res http.ResponseWriter, req *http.Request) {
+	method := strings.TrimPrefix(req.URL.Path, "/")
+	res.Header().Set("Content-Type", "application/json")
+
+	switch method {
+	case "views.open", "views.push", "views.update":
+		var body struct {
+			View   json.RawMessage `json:"view"`
+			ViewID string          `json:"view_id"`
+		}
+
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var view slack.View
+		if err := json.Unmarshal(body.View, &view); err != nil {
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		fake.mu.Lock()
+		view.ID = body.ViewID
+		if view.ID == "" {
+			fake.views++
+			view.ID = "V" + strconv.Itoa(fake.views)
+		}
+		view.Hash = view.ID + "-" + strconv.Itoa(len(fake.calls))
+		fake.calls = append(fake.calls, slackCall{Method: method, View: view})
+		fake.mu.Unlock()
+
+		json.NewEncoder(res).Encode(slack.ViewResponse{
+			SlackResponse: slack.SlackResponse{Ok: true},
+			View:          view,
+		})
+	case "chat.postMessage", "chat.update":
+		req.ParseForm()
+
+		if req.Form.Get("channel") == notMemberChannel {
+			json.NewEncoder(res).Encode(slack.SlackResponse{Ok: false, Error: "not_in_channel"})
+			return
+		}
+		call := slackCall{
+			Method:    method,
+			Channel:   req.Form.Get("channel"),
+			Timestamp: req.Form.Get("ts"),
+			Text:      req.Form.Get("text"),
+			Blocks:    req.Form.Get("blocks"),
+		}
+
+		if call.Timestamp == "" {
+			call.Timestamp = strconv.FormatInt(time.Now().UnixNano(), 10)
+		}
+
+		fake.mu.Lock()
+		fake.calls = append(fake.calls, call)
+		fake.mu.Unlock()
+
+		json.NewEncoder(res).Encode(map[string]interface{}{
+			"ok":      true,
+			"channel": call.Channel,
+			"ts":      call.Timestamp,
+			"text":    call.Text,
+		})
+	case "pins.add":
+		req.ParseForm()
+
+		fake.mu.Lock()
+		fake.calls = append(fake.calls, slackCall{Method: method, Channel: req.Form.Get("channel"), Timestamp: req.Form.Get("timestamp")})
+		fake.mu.Unlock()
+
+		json.NewEncoder(res).Encode(slack.SlackResponse{Ok: true})
+	case "users.info":
+		req.ParseForm()
+		id := req.Form.Get("user")
+		name, ok := fakeUsers[id]
+
+		fake.mu.Lock()
+		fake.calls = append(fake.calls, slackCall{Method: method, Text: id})
+		fake.mu.Unlock()
+
+		if !ok {
+			json.NewEncoder(res).Encode(slack.SlackResponse{Ok: false, Error: "user_not_found"})
+			return
+		}
+
+		json.NewEncoder(res).Encode(map[string]interface{}{
+			"ok":   true,
+			"user": slack.User{ID: id, Name: name},
+		})
+	case "users.list":
+		var members []slack.User
+		for id, name := range fakeUsers {
+			members = append(members, slack.User{ID: id, Name: name})
+		}
+
+		fake.mu.Lock()
+		fake.calls = append(fake.calls, slackCall{Method: method})
+		fake.mu.Unlock()
+
+		json.NewEncoder(res).Encode(map[string]interface{}{
+			"ok":      true,
+			"members": members,
+		})
+	default:
+		json.NewEncoder(res).Encode(slack.SlackResponse{Ok: false, Error: "unknown_method"})
+	}
+}
+
+// lastCall returns the most recent call to method, failing the test if
+// there wasn't one.
+func (fake *fakeSlack) lastCall(t *testing.T, method string) slackCall {
+	t.Helper()
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	for i := len(fake.calls) - 1; i >= 0; i-- {
+		if fake.calls[i].Method == method {
+			return fake.calls[i]
+		}
+	}
+
+	t.Fatalf("no %s call in %+v", method, fake.calls)
+	return slackCall{}
+}
+
+// callsTo returns every call to method, oldest first.
+func (fake *fakeSlack) callsTo(method string) []slackCall {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	var calls []slackCall
+	for _, call := range fake.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+func (fake *fakeSlack) count(method string) int {
+	return len(fake.callsTo(method))
+}
+
+// signedRequest builds a form POST signed with testSigningSecret the way
+// Slack signs its requests.
+func signedRequest(path string, form url.Values) *http.Request {
+	body := form.Encode()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(testSigningSecret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+
+	return req
+}
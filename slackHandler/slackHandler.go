@@ -6,19 +6,24 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"os"
 
-	"github.com/joho/godotenv"
 	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
 	"gitlab.sweetwater.com/mike_mayo/slackbot/args"
 )
 
-var err = godotenv.Load(".env")
-var signing_secret string = os.Getenv("SIGNING_SECRET")
-var api = slack.New(os.Getenv("OAUTH_TOKEN"), slack.OptionDebug(true))
+// Handler verifies requests from Slack and passes them on to app.
+type Handler struct {
+	app           *args.App
+	signingSecret string
+}
+
+func New(app *args.App, signingSecret string) *Handler {
+	return &Handler{app: app, signingSecret: signingSecret}
+}
 
-func verifySlack(req *http.Request) error {
-	verifier, err := slack.NewSecretsVerifier(req.Header, signing_secret)
+func (handler *Handler) verifySlack(req *http.Request) error {
+	verifier, err := slack.NewSecretsVerifier(req.Header, handler.signingSecret)
 	if err != nil {
 		fmt.Println(err.Error())
 		return err
@@ -41,8 +46,8 @@ func verifySlack(req *http.Request) error {
 	return nil
 }
 
-func SlashCommandHandler(res http.ResponseWriter, req *http.Request) {
-	err := verifySlack(req)
+func (handler *Handler) SlashCommandHandler(res http.ResponseWriter, req *http.Request) {
+	err := handler.verifySlack(req)
 
 	if err != nil {
 		res.WriteHeader(http.StatusUnauthorized)
@@ -51,17 +56,29 @@ func SlashCommandHandler(res http.ResponseWriter, req *http.Request) {
 
 	command, err := slack.SlashCommandParse(req)
 
+	if err != nil {
+		fmt.Printf("%+v", err)
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	handler.dispatchCommand(res, command)
+}
+
+// dispatchCommand, dispatchInteraction and dispatchEvent are shared by the
+// HTTP handlers and Socket Mode.
+func (handler *Handler) dispatchCommand(res http.ResponseWriter, command slack.SlashCommand) {
 	switch command.Command {
 	case "/angrms":
-		args.CheckArgs(res, command)
+		handler.app.CheckArgs(res, command)
 	default:
 		res.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func InteractiveHandler(res http.ResponseWriter, req *http.Request) {
-	err := verifySlack(req)
+func (handler *Handler) InteractiveHandler(res http.ResponseWriter, req *http.Request) {
+	err := handler.verifySlack(req)
 	if err != nil {
 		res.WriteHeader(http.StatusUnauthorized)
 		return
@@ -76,20 +93,85 @@ func InteractiveHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	handler.dispatchInteraction(res, modalRes)
+}
+
+func (handler *Handler) dispatchInteraction(res http.ResponseWriter, modalRes slack.InteractionCallback) {
+	switch modalRes.Type {
+	case slack.InteractionTypeShortcut, slack.InteractionTypeMessageAction:
+		handler.app.Shortcut(modalRes, res)
+		return
+	case slack.InteractionTypeBlockActions:
+		// Buttons in channel messages and the App Home aren't part of any modal.
+		if modalRes.View.ID == "" || modalRes.View.Type == slack.VTHomeTab {
+			handler.app.BlockAction(modalRes, res)
+			return
+		}
+	}
+
 	switch modalRes.View.CallbackID {
 	case "create":
-		args.SaveNewGame(modalRes, res)
+		handler.app.SaveNewGame(modalRes, res)
 	case "play":
-		args.PlayGame(modalRes, res)
+		handler.app.PlayGame(modalRes, res)
 	case "find":
-		args.StartGame(modalRes, res)
+		handler.app.StartGame(modalRes, res)
 	case "main":
-		args.ParseMenu(modalRes, res)
+		handler.app.ParseMenu(modalRes, res)
 	case "stats":
-		args.StatsInitView(res, modalRes.TriggerID, true)
+		handler.app.StatsInitView(res, modalRes.TriggerID, true)
 	case "gamestats":
-		args.ShowStats(modalRes, res)
+		handler.app.ShowStats(modalRes, res)
 	default:
 		res.WriteHeader(http.StatusInternalServerError)
 	}
 }
+
+func (handler *Handler) EventsHandler(res http.ResponseWriter, req *http.Request) {
+	err := handler.verifySlack(req)
+	if err != nil {
+		res.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		fmt.Printf("%+v", err)
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	event, err := slackevents.ParseEvent(json.RawMessage(body), slackevents.OptionNoVerifyToken())
+	if err != nil {
+		fmt.Printf("%+v", err)
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	switch event.Type {
+	case slackevents.URLVerification:
+		var challenge slackevents.ChallengeResponse
+		err = json.Unmarshal(body, &challenge)
+
+		if err != nil {
+			fmt.Printf("%+v", err)
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		res.Header().Add("Content-Type", "text/plain")
+		res.Write([]byte(challenge.Challenge))
+	case slackevents.CallbackEvent:
+		// Slack wants an answer within three seconds.
+		go handler.dispatchEvent(event)
+	}
+}
+
+func (handler *Handler) dispatchEvent(event slackevents.EventsAPIEvent) {
+	switch inner := event.InnerEvent.Data.(type) {
+	case *slackevents.AppHomeOpenedEvent:
+		if inner.Tab == "home" {
+			handler.app.PublishHome(inner.User)
+		}
+	}
+}
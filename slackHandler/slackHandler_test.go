@@ -0,0 +1,487 @@
+package slackHandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/args"
+	"gitlab.sweetwater.com/mike_mayo/slackbot/slices"
+)
+
+const (
+	testTeam = "T1"
+	testUser = "jane.doe"
+)
+
+func init() {
+	slices.Register(testTeam, slices.NewIndex([]string{"bat", "tab", "abbot", "boat"}))
+}
+
+// testApp runs the real handlers against a fake Slack and an in-memory
+// store.
+type testApp struct {
+	t       *testing.T
+	slack   *fakeSlack
+	store   args.Store
+	handler *Handler
+}
+
+func newTestApp(t *testing.T) *testApp {
+	fake := newFakeSlack(t)
+	store := args.NewMemoryStore()
+	api := slack.New("xoxb-test", slack.OptionAPIURL(fake.URL+"/"))
+
+	return &testApp{
+		t:       t,
+		slack:   fake,
+		store:   store,
+		handler: New(args.New(store, api), testSigningSecret),
+	}
+}
+
+func (app *testApp) command(text string) *httptest.ResponseRecorder {
+	return app.commandIn("C1", text)
+}
+
+func (app *testApp) commandIn(channel string, text string) *httptest.ResponseRecorder {
+	form := url.Values{
+		"command":    {"/angrms"},
+		"text":       {text},
+		"team_id":    {testTeam},
+		"channel_id": {channel},
+		"user_id":    {"U1"},
+		"user_name":  {testUser},
+		"trigger_id": {"trigger"},
+	}
+
+	res := httptest.NewRecorder()
+	app.handler.SlashCommandHandler(res, signedRequest("/", form))
+
+	return res
+}
+
+func (app *testApp) interact(callback slack.InteractionCallback) *httptest.ResponseRecorder {
+	callback.Team = slack.Team{ID: testTeam}
+	callback.User = slack.User{ID: "U1", Name: testUser}
+	callback.TriggerID = "trigger"
+
+	payload, err := json.Marshal(&callback)
+
+	if err != nil {
+		app.t.Fatal(err)
+	}
+
+	res := httptest.NewRecorder()
+	app.handler.InteractiveHandler(res, signedRequest("/interactive", url.Values{"payload": {string(payload)}}))
+
+	return res
+}
+
+// play picks the game from /angrms find and returns the play modal pushed
+// for it.
+func (app *testApp) play(gameID string) slack.View {
+	app.t.Helper()
+
+	app.command("find")
+	app.interact(slack.InteractionCallback{
+		Type: slack.InteractionTypeBlockActions,
+		View: app.slack.lastCall(app.t, "views.open").View,
+		ActionCallback: slack.ActionCallbacks{BlockActions: []*slack.BlockAction{
+			{ActionID: gameID, BlockID: "game", SelectedOption: slack.OptionBlockObject{Value: gameID}},
+		}},
+	})
+
+	view := app.slack.lastCall(app.t, "views.push").View
+
+	if view.CallbackID != "play" || view.PrivateMetadata != gameID {
+		app.t.Fatalf("play modal: got %q for %q", view.CallbackID, view.PrivateMetadata)
+	}
+
+	return view
+}
+
+// guess types word into the play modal and returns the modal it was
+// updated to.
+func (app *testApp) guess(view slack.View, word string) slack.View {
+	app.t.Helper()
+
+	var blockID string
+	for _, block := range view.Blocks.BlockSet {
+		if input, ok := block.(*slack.InputBlock); ok {
+			blockID = input.BlockID
+		}
+	}
+
+	view.State = &slack.ViewState{Values: map[string]map[string]slack.BlockAction{
+		blockID: {"letters": {Value: word}},
+	}}
+
+	res := app.interact(slack.InteractionCallback{
+		Type: slack.InteractionTypeBlockActions,
+		View: view,
+		ActionCallback: slack.ActionCallbacks{BlockActions: []*slack.BlockAction{
+			{ActionID: "letters", BlockID: blockID, Value: word},
+		}},
+	})
+
+	if res.Code != http.StatusOK {
+		app.t.Fatalf("guessing %q: status %d", word, res.Code)
+	}
+
+	return app.slack.lastCall(app.t, "views.update").View
+}
+
+func viewText(t *testing.T, view slack.View) string {
+	text, err := json.Marshal(view)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(text)
+}
+
+func TestRejectsUnsignedRequests(t *testing.T) {
+	app := newTestApp(t)
+
+	req := signedRequest("/", url.Values{"command": {"/angrms"}})
+	req.Header.Set("X-Slack-Signature", "v0=bad")
+
+	res := httptest.NewRecorder()
+	app.handler.SlashCommandHandler(res, req)
+
+	if res.Code != http.StatusUnauthorized {
+		t.Errorf("got status %d", res.Code)
+	}
+
+	if count := app.slack.count("views.open"); count != 0 {
+		t.Errorf("opened %d views", count)
+	}
+}
+
+func TestCommands(t *testing.T) {
+	app := newTestApp(t)
+
+	tests := []struct {
+		text       string
+		callbackID string
+	}{
+		{"", "main"},
+		{"create", "create"},
+		{"find", "find"},
+	}
+
+	for _, test := range tests {
+		if res := app.command(test.text); res.Code != http.StatusOK {
+			t.Fatalf("%q: status %d", test.text, res.Code)
+		}
+
+		if view := app.slack.lastCall(t, "views.open").View; view.CallbackID != test.callbackID {
+			t.Errorf("%q: opened %q", test.text, view.CallbackID)
+		}
+	}
+
+	if res := app.command("dance"); !strings.Contains(res.Body.String(), "Only the following commands") {
+		t.Errorf("unknown command: got %q", res.Body.String())
+	}
+}
+
+func TestCreateAndSolveGame(t *testing.T) {
+	app := newTestApp(t)
+
+	app.command("create")
+	createView := app.slack.lastCall(t, "views.open").View
+	createView.State = &slack.ViewState{Values: map[string]map[string]slack.BlockAction{
+		"letters": {"letters": {Value: "TAB"}},
+	}}
+
+	res := app.interact(slack.InteractionCallback{Type: slack.InteractionTypeViewSubmission, View: createView})
+
+	if !strings.Contains(res.Body.String(), `"response_action":"update"`) || !strings.Contains(res.Body.String(), "2 words to find") {
+		t.Fatalf("create: got %q", res.Body.String())
+	}
+
+	games, _ := app.store.FindGames(args.GameQuery{})
+
+	if len(games) != 1 || games[0].Letters != "tab" {
+		t.Fatalf("stored %+v", games)
+	}
+
+	view := app.play(games[0].Id.Hex())
+
+	view = app.guess(view, "bat")
+	if text := viewText(t, view); !strings.Contains(text, "1 words left") || !strings.Contains(text, `"text":"bat"`) {
+		t.Errorf("correct guess: got %s", text)
+	}
+
+	view = app.guess(view, "BAT")
+	if text := viewText(t, view); !strings.Contains(text, "already guessed!") {
+		t.Errorf("repeated guess: got %s", text)
+	}
+
+	view = app.guess(view, "boat")
+	if text := viewText(t, view); !strings.Contains(text, "is incorrect!") {
+		t.Errorf("wrong guess: got %s", text)
+	}
+
+	view = app.guess(view, "tab")
+	if view.Title == nil || !strings.HasPrefix(view.Title.Text, "Solved!!") {
+		t.Errorf("last word: got %s", viewText(t, view))
+	}
+
+	game, _ := app.store.GetGame(games[0].Id)
+
+	if len(game.Leaderboard) != 1 || game.Leaderboard[0].User != testUser {
+		t.Errorf("leaderboard %+v", game.Leaderboard)
+	}
+}
+
+func TestDefineWord(t *testing.T) {
+	slices.SetDefiner(slices.Lexicon{"tab": "a small flap"})
+	defer slices.SetDefiner(slices.Lexicon{})
+
+	app := newTestApp(t)
+	game, _ := app.store.InsertGame(args.Game{User: testUser, Letters: "abot", Words: []string{"bat", "tab", "boat"}, Active: true})
+
+	view := app.guess(app.play(game.Id.Hex()), "bat")
+	if text := viewText(t, view); strings.Contains(text, "Define a word") {
+		t.Errorf("offered a word with no definition: %s", text)
+	}
+
+	view = app.guess(view, "tab")
+	if text := viewText(t, view); !strings.Contains(text, "Define a word") || strings.Contains(text, `"value":"bat"`) {
+		t.Errorf("define menu: got %s", text)
+	}
+
+	pushes := app.slack.count("views.push")
+	app.interact(slack.InteractionCallback{
+		Type: slack.InteractionTypeBlockActions,
+		View: view,
+		ActionCallback: slack.ActionCallbacks{BlockActions: []*slack.BlockAction{
+			{ActionID: "define", BlockID: "define", SelectedOption: slack.OptionBlockObject{Value: "tab"}},
+		}},
+	})
+
+	if app.slack.count("views.push") != pushes {
+		t.Error("pushed a view for the definition")
+	}
+
+	view = app.slack.lastCall(t, "views.update").View
+	if text := viewText(t, view); view.CallbackID != "play" || !strings.Contains(text, "a small flap") || !strings.Contains(text, "1 words left") {
+		t.Errorf("definition: got %s", text)
+	}
+}
+
+func TestHomeMenuOpensViews(t *testing.T) {
+	app := newTestApp(t)
+
+	for _, action := range []string{"stats", "tips"} {
+		app.interact(slack.InteractionCallback{
+			Type: slack.InteractionTypeBlockActions,
+			View: slack.View{ID: "VHOME", Type: slack.VTHomeTab},
+			ActionCallback: slack.ActionCallbacks{BlockActions: []*slack.BlockAction{
+				{ActionID: action, BlockID: "home-actions", Value: action},
+			}},
+		})
+	}
+
+	if opened, pushed := app.slack.count("views.open"), app.slack.count("views.push"); opened != 2 || pushed != 0 {
+		t.Errorf("opened %d and pushed %d views", opened, pushed)
+	}
+}
+
+func TestAnnounceCreatedGame(t *testing.T) {
+	t.Setenv("ANNOUNCE_CHANNEL", "C9")
+	app := newTestApp(t)
+
+	app.command("create")
+	createView := app.slack.lastCall(t, "views.open").View
+	createView.State = &slack.ViewState{Values: map[string]map[string]slack.BlockAction{
+		"letters": {"letters": {Value: "TAB"}},
+	}}
+
+	app.interact(slack.InteractionCallback{Type: slack.InteractionTypeViewSubmission, View: createView})
+
+	games, _ := app.store.FindGames(args.GameQuery{})
+	if len(games) != 1 {
+		t.Fatalf("stored %+v", games)
+	}
+
+	post := app.slack.lastCall(t, "chat.postMessage")
+
+	if post.Channel != "C9" || !strings.Contains(post.Text, "created an Angrms game: *TAB* - 2 words to find") {
+		t.Errorf("announcement: got %+v", post)
+	}
+
+	if !strings.Contains(post.Blocks, `"action_id":"play-game"`) || !strings.Contains(post.Blocks, `"value":"`+games[0].Id.Hex()+`"`) {
+		t.Errorf("announcement has no Play button: %s", post.Blocks)
+	}
+}
+
+func TestChallenge(t *testing.T) {
+	t.Setenv("ANNOUNCE_CHANNEL", "C9")
+	app := newTestApp(t)
+
+	res := app.command("challenge <@U2> tab")
+
+	if !strings.Contains(res.Body.String(), "Challenge sent to <@U2>") {
+		t.Fatalf("challenge: got %q", res.Body.String())
+	}
+
+	if lookup := app.slack.lastCall(t, "users.info"); lookup.Text != "U2" {
+		t.Errorf("looked up %q", lookup.Text)
+	}
+
+	posts := app.slack.callsTo("chat.postMessage")
+	if len(posts) != 2 || posts[0].Channel != "U1" || posts[1].Channel != "U2" {
+		t.Fatalf("expected a DM to each player, got %+v", posts)
+	}
+
+	for _, post := range posts {
+		if !strings.Contains(post.Text, "started an Angrms challenge with <@U2>") || !strings.Contains(post.Text, "*TAB* - 2 words") {
+			t.Errorf("DM to %s: got %q", post.Channel, post.Text)
+		}
+	}
+
+	games, _ := app.store.FindGames(args.GameQuery{Challenge: true})
+	if len(games) != 1 || !reflect.DeepEqual(games[0].Challenge.Players, []string{testUser, "john.doe"}) || !games[0].Private {
+		t.Fatalf("stored %+v", games)
+	}
+
+	// Plain @name mentions are looked up in the member list.
+	app.command("challenge @john.doe tab")
+
+	if app.slack.count("users.list") != 1 {
+		t.Error("didn't look up the member list")
+	}
+
+	if posts := app.slack.callsTo("chat.postMessage"); len(posts) != 4 || posts[3].Channel != "U2" {
+		t.Errorf("second challenge: got %+v", posts)
+	}
+}
+
+func TestTeamGame(t *testing.T) {
+	app := newTestApp(t)
+
+	app.command("team tab")
+
+	post := app.slack.lastCall(t, "chat.postMessage")
+	if post.Channel != "C1" || !strings.Contains(post.Text, "Team game: TAB") || !strings.Contains(post.Text, "0 of 2 words found") {
+		t.Fatalf("scoreboard: got %+v", post)
+	}
+
+	if pin := app.slack.lastCall(t, "pins.add"); pin.Channel != "C1" || pin.Timestamp != post.Timestamp {
+		t.Errorf("pinned %+v, posted %s", pin, post.Timestamp)
+	}
+
+	games, _ := app.store.FindGames(args.GameQuery{})
+	if len(games) != 1 || games[0].Shared == nil || games[0].Shared.MessageTS != post.Timestamp {
+		t.Fatalf("stored %+v", games)
+	}
+
+	gameID := games[0].Id.Hex()
+
+	if !strings.Contains(post.Blocks, `"action_id":"play-game"`) || !strings.Contains(post.Blocks, `"value":"`+gameID+`"`) {
+		t.Errorf("scoreboard has no Play button: %s", post.Blocks)
+	}
+
+	// Team games belong to their channel, so they aren't listed for everyone.
+	app.command("find")
+	if text := viewText(t, app.slack.lastCall(t, "views.open").View); strings.Contains(text, gameID) {
+		t.Errorf("team game listed in find: %s", text)
+	}
+
+	app.interact(slack.InteractionCallback{
+		Type: slack.InteractionTypeBlockActions,
+		ActionCallback: slack.ActionCallbacks{BlockActions: []*slack.BlockAction{
+			{ActionID: "play-game", BlockID: "play-" + gameID, Value: gameID},
+		}},
+	})
+
+	view := app.slack.lastCall(t, "views.open").View
+	if view.CallbackID != "play" || view.PrivateMetadata != gameID {
+		t.Fatalf("Play button: got %q for %q", view.CallbackID, view.PrivateMetadata)
+	}
+
+	view = app.guess(view, "bat")
+
+	update := app.slack.lastCall(t, "chat.update")
+	if update.Channel != "C1" || update.Timestamp != post.Timestamp || !strings.Contains(update.Text, "1 of 2 words found") || !strings.Contains(update.Blocks, "bat") {
+		t.Errorf("scoreboard update: got %+v", update)
+	}
+
+	app.guess(view, "tab")
+
+	if update := app.slack.lastCall(t, "chat.update"); !strings.Contains(update.Text, "Every one of the 2 words has been found") {
+		t.Errorf("final scoreboard: got %+v", update)
+	}
+
+	if post := app.slack.lastCall(t, "chat.postMessage"); post.Channel != "C1" || !strings.Contains(post.Text, "this channel found every word in *TAB*") {
+		t.Errorf("celebration: got %+v", post)
+	}
+
+	if game, _ := app.store.GetGame(games[0].Id); game.Active {
+		t.Error("team game still active after every word was found")
+	}
+}
+
+func TestTeamGameNotInChannel(t *testing.T) {
+	app := newTestApp(t)
+
+	res := app.commandIn(notMemberChannel, "team tab")
+
+	if !strings.Contains(res.Body.String(), "Is Angrms a member of it?") {
+		t.Errorf("got %q", res.Body.String())
+	}
+
+	if games, _ := app.store.FindGames(args.GameQuery{}); len(games) != 0 {
+		t.Errorf("saved a game with no message: %+v", games)
+	}
+}
+
+func TestChallengeWinnerFoundMostWords(t *testing.T) {
+	app := newTestApp(t)
+
+	app.command("challenge <@U2> abot")
+
+	games, _ := app.store.FindGames(args.GameQuery{Challenge: true})
+	if len(games) != 1 || len(games[0].Words) != 2 {
+		t.Fatalf("stored %+v", games)
+	}
+
+	// john.doe got to boat first, so finding every word only earns a draw.
+	app.store.ClaimFirst(games[0].Id, args.FirstFind{Word: "boat", User: "john.doe", Date: time.Now()})
+
+	view := app.guess(app.play(games[0].Id.Hex()), "abbot")
+	app.guess(view, "boat")
+
+	posts := app.slack.callsTo("chat.postMessage")
+	if last := posts[len(posts)-1]; !strings.Contains(last.Text, "challenge is over. It's a draw!") {
+		t.Errorf("result: got %q", last.Text)
+	}
+
+	if game, _ := app.store.GetGame(games[0].Id); game.Active || game.Challenge.Winner != "" {
+		t.Errorf("closed challenge %+v", game.Challenge)
+	}
+}
+
+func TestCreateRandomLetterCount(t *testing.T) {
+	app := newTestApp(t)
+
+	for _, n := range []string{"3", "13", "many"} {
+		if res := app.command("create random " + n); !strings.Contains(res.Body.String(), "needs a number of letters between 4 and 12") {
+			t.Errorf("%s: got %q", n, res.Body.String())
+		}
+	}
+
+	if games, _ := app.store.FindGames(args.GameQuery{}); len(games) != 0 {
+		t.Errorf("created %+v", games)
+	}
+}
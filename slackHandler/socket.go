@@ -0,0 +1,115 @@
+package slackHandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+)
+
+// socketResponse collects what a handler would have written to Slack over
+// HTTP so it can be sent back as the Socket Mode acknowledgement.
+type socketResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newSocketResponse() *socketResponse {
+	return &socketResponse{header: make(http.Header), status: http.StatusOK}
+}
+
+func (res *socketResponse) Header() http.Header {
+	return res.header
+}
+
+func (res *socketResponse) Write(body []byte) (int, error) {
+	return res.body.Write(body)
+}
+
+func (res *socketResponse) WriteHeader(status int) {
+	res.status = status
+}
+
+// payload is the acknowledgement's payload. JSON responses like view
+// submission errors are passed on as they are, and plain text becomes a
+// message.
+func (res *socketResponse) payload() interface{} {
+	if res.status != http.StatusOK || res.body.Len() == 0 {
+		return nil
+	}
+
+	if res.header.Get("Content-Type") == "application/json" {
+		return json.RawMessage(res.body.Bytes())
+	}
+
+	return map[string]string{"text": res.body.String()}
+}
+
+func ack(socket *socketmode.Client, event socketmode.Event, res *socketResponse) {
+	if event.Request == nil {
+		return
+	}
+
+	if payload := res.payload(); payload != nil {
+		socket.Ack(*event.Request, payload)
+		return
+	}
+
+	socket.Ack(*event.Request)
+}
+
+// RunSocketMode receives commands, interactions and events over a Socket
+// Mode websocket instead of HTTP. client needs an app level token, and it
+// blocks until the connection fails.
+func (handler *Handler) RunSocketMode(client *slack.Client) error {
+	socket := socketmode.New(client)
+
+	go func() {
+		for event := range socket.Events {
+			switch event.Type {
+			case socketmode.EventTypeConnecting:
+				fmt.Println("Connecting to Slack with Socket Mode...")
+			case socketmode.EventTypeConnected:
+				fmt.Println("Connected to Slack with Socket Mode")
+			case socketmode.EventTypeConnectionError:
+				fmt.Println("Socket Mode connection failed, retrying...")
+			case socketmode.EventTypeSlashCommand:
+				command, ok := event.Data.(slack.SlashCommand)
+				if !ok {
+					continue
+				}
+
+				res := newSocketResponse()
+				handler.dispatchCommand(res, command)
+				ack(socket, event, res)
+			case socketmode.EventTypeInteractive:
+				callback, ok := event.Data.(slack.InteractionCallback)
+				if !ok {
+					continue
+				}
+
+				res := newSocketResponse()
+				handler.dispatchInteraction(res, callback)
+				ack(socket, event, res)
+			case socketmode.EventTypeEventsAPI:
+				eventsAPIEvent, ok := event.Data.(slackevents.EventsAPIEvent)
+				if !ok {
+					continue
+				}
+
+				socket.Ack(*event.Request)
+
+				if eventsAPIEvent.Type == slackevents.CallbackEvent {
+					go handler.dispatchEvent(eventsAPIEvent)
+				}
+			}
+		}
+	}()
+
+	return socket.Run()
+}
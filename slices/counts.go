@@ -0,0 +1,35 @@
+package slices
+
+import "strings"
+
+// Counts is a multiset of letters, indexed from 'a' to 'z'.
+type Counts [26]int
+
+func LetterCounts(letters string) Counts {
+	var counts Counts
+
+	for _, letter := range strings.ToLower(letters) {
+		if letter >= 'a' && letter <= 'z' {
+			counts[letter-'a']++
+		}
+	}
+
+	return counts
+}
+
+// Spells reports whether word can be made without using any letter more
+// times than it appears in counts.
+func (counts Counts) Spells(word string) bool {
+	for _, letter := range word {
+		if letter < 'a' || letter > 'z' {
+			return false
+		}
+
+		counts[letter-'a']--
+		if counts[letter-'a'] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,63 @@
+package slices
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Definer looks up a short definition for a word.
+type Definer interface {
+	Define(word string) (string, bool)
+}
+
+// Lexicon is an offline set of definitions keyed by lowercase word.
+type Lexicon map[string]string
+
+var (
+	definer   Definer = Lexicon{}
+	definerMu sync.RWMutex
+)
+
+func (lexicon Lexicon) Define(word string) (string, bool) {
+	definition, ok := lexicon[strings.ToLower(word)]
+	return definition, ok
+}
+
+// LoadLexicon reads a JSON object mapping words to their definitions, kept
+// next to words.json.
+func LoadLexicon(path string) (Lexicon, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot open %s: %w", path, err)
+	}
+
+	var raw map[string]string
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal %s: %w", path, err)
+	}
+
+	lexicon := make(Lexicon, len(raw))
+	for word, definition := range raw {
+		lexicon[strings.ToLower(word)] = definition
+	}
+
+	return lexicon, nil
+}
+
+func SetDefiner(d Definer) {
+	definerMu.Lock()
+	defer definerMu.Unlock()
+
+	definer = d
+}
+
+func Define(word string) (string, bool) {
+	definerMu.RLock()
+	defer definerMu.RUnlock()
+
+	return definer.Define(word)
+}
@@ -0,0 +1,175 @@
+package slices
+
+import (
+	"bufio"
+	"fmt"
+	"math/bits"
+	"os"
+	"strings"
+	"sync"
+
+	"gitlab.sweetwater.com/mike_mayo/slackbot/util"
+)
+
+// Dictionary finds the words that can be spelled from a set of letters. When
+// all is true every one of the letters has to appear in each word.
+type Dictionary interface {
+	Find(letters string, all bool) ([]string, error)
+}
+
+// MaxLetters is the most distinct letters a dictionary will look for
+// subsets of. Every extra letter doubles the work.
+const MaxLetters = 12
+
+var ErrTooManyLetters = fmt.Errorf("games can use at most %d different letters", MaxLetters)
+
+var (
+	dictionaries   = make(map[string]Dictionary)
+	dictionariesMu sync.RWMutex
+)
+
+func (index *Index) Find(letters string, all bool) ([]string, error) {
+	mask := lettersMask(letters)
+
+	if all {
+		return index.Exact(mask), nil
+	}
+
+	if bits.OnesCount32(mask) > MaxLetters {
+		return nil, ErrTooManyLetters
+	}
+
+	return index.Subsets(mask), nil
+}
+
+// Register makes d the dictionary for a Slack team. An empty team sets the
+// default used by every team without its own list.
+func Register(team string, d Dictionary) {
+	dictionariesMu.Lock()
+	defer dictionariesMu.Unlock()
+
+	dictionaries[team] = d
+}
+
+func ForTeam(team string) (Dictionary, error) {
+	dictionariesMu.RLock()
+	defer dictionariesMu.RUnlock()
+
+	if d, ok := dictionaries[team]; ok {
+		return d, nil
+	}
+
+	if d, ok := dictionaries[""]; ok {
+		return d, nil
+	}
+
+	return nil, fmt.Errorf("no dictionary loaded for team %q", team)
+}
+
+func LoadJSON(path string) (*Index, error) {
+	words, err := readJSONWords(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIndex(words), nil
+}
+
+// LoadText reads a file with one word per line.
+func LoadText(path string) (*Index, error) {
+	words, err := readTextWords(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIndex(words), nil
+}
+
+// ReadWords reads the words from a .json file in the words.json format or
+// from a file with one word per line.
+func ReadWords(path string) ([]string, error) {
+	if strings.HasSuffix(path, ".json") {
+		return readJSONWords(path)
+	}
+
+	return readTextWords(path)
+}
+
+func readTextWords(path string) ([]string, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		words = append(words, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", path, err)
+	}
+
+	return words, nil
+}
+
+// OpenSource opens a dictionary from a source string: "mongo:<collection>"
+// for a Mongo collection, a .json path for a words.json style map, or any
+// other path for a newline separated word file.
+func OpenSource(source string) (Dictionary, error) {
+	switch {
+	case strings.HasPrefix(source, "mongo:"):
+		collection := strings.TrimPrefix(source, "mongo:")
+		return NewMongoDictionary(util.MongoClient().Database("slack").Collection(collection)), nil
+	case strings.HasSuffix(source, ".json"):
+		return LoadJSON(source)
+	default:
+		return LoadText(source)
+	}
+}
+
+// LoadDictionaries registers the default dictionary and any per-team ones.
+// teamSources is a comma separated list of team_id=source pairs, for example
+// "T0123=jargon.txt,T0456=mongo:words".
+func LoadDictionaries(defaultSource string, teamSources string) error {
+	if defaultSource == "" {
+		defaultSource = "words.json"
+	}
+
+	d, err := OpenSource(defaultSource)
+
+	if err != nil {
+		return err
+	}
+
+	Register("", d)
+
+	for _, pair := range strings.Split(teamSources, ",") {
+		pair = strings.TrimSpace(pair)
+
+		if pair == "" {
+			continue
+		}
+
+		team, source, ok := strings.Cut(pair, "=")
+
+		if !ok {
+			return fmt.Errorf("team dictionary %q should look like team_id=source", pair)
+		}
+
+		d, err := OpenSource(strings.TrimSpace(source))
+
+		if err != nil {
+			return err
+		}
+
+		Register(strings.TrimSpace(team), d)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package slices
+
+import (
+	"sort"
+	"strings"
+)
+
+// Index groups dictionary words by the set of distinct letters they use. The
+// set is stored as a bitmask where bit 0 is 'a' and bit 25 is 'z'.
+type Index struct {
+	words map[uint32][]string
+	size  int
+}
+
+func NewIndex(words []string) *Index {
+	index := &Index{words: make(map[uint32][]string)}
+
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		mask, ok := letterMask(word)
+
+		if !ok || mask == 0 {
+			continue
+		}
+
+		index.words[mask] = append(index.words[mask], word)
+		index.size++
+	}
+
+	for _, group := range index.words {
+		sort.Strings(group)
+	}
+
+	return index
+}
+
+func letterMask(word string) (uint32, bool) {
+	var mask uint32
+
+	for _, letter := range word {
+		if letter < 'a' || letter > 'z' {
+			return 0, false
+		}
+
+		mask |= 1 << uint(letter-'a')
+	}
+
+	return mask, true
+}
+
+// lettersMask is letterMask for user input: case and anything that isn't a
+// letter are ignored.
+func lettersMask(letters string) uint32 {
+	var mask uint32
+
+	for _, letter := range strings.ToLower(letters) {
+		if letter >= 'a' && letter <= 'z' {
+			mask |= 1 << uint(letter-'a')
+		}
+	}
+
+	return mask
+}
+
+func (index *Index) Len() int {
+	return index.size
+}
+
+// Exact returns the words that use every letter in mask and nothing else.
+func (index *Index) Exact(mask uint32) []string {
+	return append([]string(nil), index.words[mask]...)
+}
+
+// Subsets returns the words made only from letters in mask, found by
+// enumerating every non-empty submask.
+func (index *Index) Subsets(mask uint32) []string {
+	var found []string
+
+	for sub := mask; sub > 0; sub = (sub - 1) & mask {
+		found = append(found, index.words[sub]...)
+	}
+
+	sort.Strings(found)
+	return found
+}
@@ -0,0 +1,122 @@
+package slices
+
+import (
+	"context"
+	"math/bits"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MongoDictionary keeps its words in a collection of {word, mask} documents,
+// where mask is the word's distinct-letter bitmask.
+type MongoDictionary struct {
+	coll *mongo.Collection
+}
+
+type dictionaryWord struct {
+	Word string `bson:"word"`
+	Mask int64  `bson:"mask"`
+}
+
+func NewMongoDictionary(coll *mongo.Collection) *MongoDictionary {
+	return &MongoDictionary{coll: coll}
+}
+
+// findMasks lists the masks whose words Find matches. Without all that is
+// every submask of the letters, so the number of letters is capped.
+func findMasks(letters string, all bool) ([]int64, error) {
+	mask := lettersMask(letters)
+
+	if all {
+		return []int64{int64(mask)}, nil
+	}
+
+	if bits.OnesCount32(mask) > MaxLetters {
+		return nil, ErrTooManyLetters
+	}
+
+	var masks []int64
+	for sub := mask; sub > 0; sub = (sub - 1) & mask {
+		masks = append(masks, int64(sub))
+	}
+
+	return masks, nil
+}
+
+func (d *MongoDictionary) Find(letters string, all bool) ([]string, error) {
+	masks, err := findMasks(letters, all)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := d.coll.Find(context.TODO(), bson.M{"mask": bson.M{"$in": masks}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []dictionaryWord
+	if err := cursor.All(context.TODO(), &docs); err != nil {
+		return nil, err
+	}
+
+	var words []string
+	for _, doc := range docs {
+		words = append(words, doc.Word)
+	}
+
+	sort.Strings(words)
+	return words, nil
+}
+
+// dictionaryDocs builds the documents for words, skipping anything that
+// isn't made of the letters a to z and any repeats.
+func dictionaryDocs(words []string) []interface{} {
+	var docs []interface{}
+	seen := make(map[string]bool)
+
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		mask, ok := letterMask(word)
+
+		if !ok || mask == 0 || seen[word] {
+			continue
+		}
+
+		seen[word] = true
+		docs = append(docs, dictionaryWord{Word: word, Mask: int64(mask)})
+	}
+
+	return docs
+}
+
+// Add stores words in the collection.
+func (d *MongoDictionary) Add(words ...string) error {
+	docs := dictionaryDocs(words)
+
+	if len(docs) == 0 {
+		return nil
+	}
+
+	_, err := d.coll.InsertMany(context.TODO(), docs)
+	return err
+}
+
+// Load replaces everything in the collection with words and indexes it by
+// mask.
+func (d *MongoDictionary) Load(words []string) error {
+	if err := d.coll.Drop(context.TODO()); err != nil {
+		return err
+	}
+
+	if err := d.Add(words...); err != nil {
+		return err
+	}
+
+	_, err := d.coll.Indexes().CreateOne(context.TODO(), mongo.IndexModel{Keys: bson.D{{Key: "mask", Value: 1}}})
+	return err
+}
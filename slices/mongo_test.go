@@ -0,0 +1,42 @@
+package slices
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindMasks(t *testing.T) {
+	masks, err := findMasks("abt", true)
+
+	if err != nil || !reflect.DeepEqual(masks, []int64{int64(lettersMask("abt"))}) {
+		t.Errorf("all: got %v, %v", masks, err)
+	}
+
+	masks, err = findMasks("ABT", false)
+
+	if err != nil || len(masks) != 7 {
+		t.Errorf("subsets: got %v, %v", masks, err)
+	}
+
+	letters := "abcdefghijklm"[:MaxLetters]
+	if masks, err := findMasks(letters, false); err != nil || len(masks) != 1<<MaxLetters-1 {
+		t.Errorf("%d letters: got %d masks, %v", MaxLetters, len(masks), err)
+	}
+
+	if _, err := findMasks(letters+"z", false); err != ErrTooManyLetters {
+		t.Errorf("too many letters: got %v", err)
+	}
+}
+
+func TestDictionaryDocs(t *testing.T) {
+	docs := dictionaryDocs(strings.Fields("bat Tab bat can't  cat1 tab"))
+	want := []interface{}{
+		dictionaryWord{Word: "bat", Mask: int64(lettersMask("abt"))},
+		dictionaryWord{Word: "tab", Mask: int64(lettersMask("abt"))},
+	}
+
+	if !reflect.DeepEqual(docs, want) {
+		t.Errorf("got %v, want %v", docs, want)
+	}
+}
@@ -0,0 +1,96 @@
+package slices
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"time"
+)
+
+// LetterSets is implemented by dictionaries that can list the letter sets
+// their words are made from, which lets RandomLetters skip sets with no words.
+type LetterSets interface {
+	LetterSets(n int) []string
+}
+
+// Scrabble tile distribution, used to draw letters for dictionaries that
+// can't list their letter sets.
+const tileBag = "eeeeeeeeeeeeaaaaaaaaaiiiiiiiiioooooooonnnnnnrrrrrrttttttllllssssuuuuddddgggbbccmmppffhhvvwwyykjxqz"
+
+const randomDraws = 500
+
+func (index *Index) LetterSets(n int) []string {
+	var sets []string
+
+	for mask := range index.words {
+		if bits.OnesCount32(mask) == n {
+			sets = append(sets, maskLetters(mask))
+		}
+	}
+
+	return sets
+}
+
+func maskLetters(mask uint32) string {
+	var letters []byte
+
+	for i := 0; i < 26; i++ {
+		if mask&(1<<uint(i)) != 0 {
+			letters = append(letters, byte('a'+i))
+		}
+	}
+
+	return string(letters)
+}
+
+func drawLetters(rng *rand.Rand, n int) string {
+	var mask uint32
+
+	for bits.OnesCount32(mask) < n {
+		letter := tileBag[rng.Intn(len(tileBag))]
+		mask |= 1 << uint(letter-'a')
+	}
+
+	return maskLetters(mask)
+}
+
+// RandomLetters picks n distinct letters for which fits returns true. The
+// letters come back shuffled so they don't give the words away.
+func RandomLetters(d Dictionary, n int, fits func(letters string) (bool, error)) (string, error) {
+	if n < 1 || n > 26 {
+		return "", fmt.Errorf("can't pick %d letters", n)
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	var candidates []string
+	if sets, ok := d.(LetterSets); ok {
+		candidates = sets.LetterSets(n)
+		rng.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+	} else {
+		for i := 0; i < randomDraws; i++ {
+			candidates = append(candidates, drawLetters(rng, n))
+		}
+	}
+
+	for _, letters := range candidates {
+		ok, err := fits(letters)
+
+		if err != nil {
+			return "", err
+		}
+
+		if ok {
+			shuffled := []byte(letters)
+			rng.Shuffle(len(shuffled), func(i, j int) {
+				shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+			})
+
+			return string(shuffled), nil
+		}
+	}
+
+	return "", fmt.Errorf("no set of %d letters fits", n)
+}
@@ -2,64 +2,40 @@ package slices
 
 import (
 	"encoding/json"
-	"io/ioutil"
-	"log"
-	"regexp"
-	"strings"
+	"fmt"
+	"os"
 )
 
-func stringContains(letters string, slices [][]string) []string {
-	var found []string
-
-	for _, wordArray := range slices {
-		for _, word := range wordArray {
-			everyLetter := true
-			for _, letter := range letters {
-				if strings.HasPrefix(string(word), string(letter)) {
-					continue
-				}
-
-				if !strings.Contains(string(word), string(letter)) {
-					everyLetter = false
-					break
-				}
-			}
-
-			if everyLetter {
-				rgx, err := regexp.Compile("^[" + letters + "]+$")
-
-				if err != nil {
-
-				}
-
-				if rgx.MatchString(string(word)) {
-					found = append(found, string(word))
-				}
-			}
-		}
-	}
-
-	return found
-}
-
-func FindWordsWithLetters(letters string) []string {
-	content, err := ioutil.ReadFile("words.json")
+func readJSONWords(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
 
 	if err != nil {
-		log.Fatal("Cannot open file", err)
+		return nil, fmt.Errorf("cannot open %s: %w", path, err)
 	}
 
 	var payload map[string][]string
 	err = json.Unmarshal(content, &payload)
 
 	if err != nil {
-		log.Fatal("Cannot unmarshal JSON", err)
+		return nil, fmt.Errorf("cannot unmarshal %s: %w", path, err)
+	}
+
+	var words []string
+	for _, group := range payload {
+		words = append(words, group...)
 	}
 
-	var words [][]string
-	for _, letter := range letters {
-		words = append(words, payload[string(letter)])
+	return words, nil
+}
+
+// FindWordsWithLetters returns the words in the team's dictionary that use
+// every one of letters.
+func FindWordsWithLetters(team string, letters string) ([]string, error) {
+	d, err := ForTeam(team)
+
+	if err != nil {
+		return nil, err
 	}
 
-	return stringContains(letters, words)
+	return d.Find(letters, true)
 }
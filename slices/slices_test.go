@@ -0,0 +1,296 @@
+package slices
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const wordsPath = "../words.json"
+
+var benchLetters = []string{"rstlne", "aeinst", "abt", "qu", "ploicy"}
+
+// stringContains is the word finder that FindWordsWithLetters used before the
+// index existed. It is kept here to check and benchmark the index against.
+func stringContains(letters string, slices [][]string) []string {
+	var found []string
+
+	for _, wordArray := range slices {
+		for _, word := range wordArray {
+			everyLetter := true
+			for _, letter := range letters {
+				if strings.HasPrefix(string(word), string(letter)) {
+					continue
+				}
+
+				if !strings.Contains(string(word), string(letter)) {
+					everyLetter = false
+					break
+				}
+			}
+
+			if everyLetter {
+				rgx, err := regexp.Compile("^[" + letters + "]+$")
+
+				if err != nil {
+					continue
+				}
+
+				if rgx.MatchString(string(word)) {
+					found = append(found, string(word))
+				}
+			}
+		}
+	}
+
+	return found
+}
+
+func loadPayload(t testing.TB) map[string][]string {
+	words, err := readJSONWords(wordsPath)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := make(map[string][]string)
+	for _, word := range words {
+		payload[word[:1]] = append(payload[word[:1]], word)
+	}
+
+	return payload
+}
+
+func legacyFind(payload map[string][]string, letters string) []string {
+	var words [][]string
+	for _, letter := range letters {
+		words = append(words, payload[string(letter)])
+	}
+
+	return stringContains(letters, words)
+}
+
+func TestFindWordsWithLettersMatchesLegacy(t *testing.T) {
+	if err := LoadDictionaries(wordsPath, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	payload := loadPayload(t)
+
+	for _, letters := range benchLetters {
+		want := legacyFind(payload, letters)
+		sort.Strings(want)
+
+		got, err := FindWordsWithLetters("T0", letters)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(want) == 0 && len(got) == 0 {
+			continue
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", letters, got, want)
+		}
+	}
+}
+
+func TestFindWordsWithLettersUnloaded(t *testing.T) {
+	saved := dictionaries
+	dictionaries = make(map[string]Dictionary)
+	defer func() { dictionaries = saved }()
+
+	if _, err := FindWordsWithLetters("T0", "abc"); err == nil {
+		t.Error("expected an error before the dictionary is loaded")
+	}
+}
+
+func TestTeamDictionary(t *testing.T) {
+	saved := dictionaries
+	dictionaries = make(map[string]Dictionary)
+	defer func() { dictionaries = saved }()
+
+	path := filepath.Join(t.TempDir(), "jargon.txt")
+	if err := os.WriteFile(path, []byte("bat\ntab\n\nTABS\nsku\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadDictionaries(wordsPath, "T1="+path); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FindWordsWithLetters("T1", "bat")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"bat", "tab"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("team dictionary: got %v, want %v", got, want)
+	}
+
+	got, err = FindWordsWithLetters("T2", "sku")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 0 {
+		t.Errorf("default dictionary: got %v, want no words", got)
+	}
+}
+
+func TestSubsets(t *testing.T) {
+	index := NewIndex([]string{"tab", "bat", "at", "a", "cat", "Tabs"})
+	got := index.Subsets(lettersMask("abt"))
+	want := []string{"a", "at", "bat", "tab"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFindTooManyLetters(t *testing.T) {
+	index := NewIndex([]string{"tab", "bat"})
+
+	if _, err := index.Find("abcdefghijklmt", false); err != ErrTooManyLetters {
+		t.Errorf("subsets: got %v", err)
+	}
+
+	if words, err := index.Find("abcdefghijklmt", true); err != nil || len(words) != 0 {
+		t.Errorf("exact: got %v, %v", words, err)
+	}
+}
+
+func BenchmarkStringContains(b *testing.B) {
+	payload := loadPayload(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, letters := range benchLetters {
+			legacyFind(payload, letters)
+		}
+	}
+}
+
+func BenchmarkIndexExact(b *testing.B) {
+	words, err := readJSONWords(wordsPath)
+
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	index := NewIndex(words)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, letters := range benchLetters {
+			index.Exact(lettersMask(letters))
+		}
+	}
+}
+
+func BenchmarkIndexSubsets(b *testing.B) {
+	words, err := readJSONWords(wordsPath)
+
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	index := NewIndex(words)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, letters := range benchLetters {
+			index.Subsets(lettersMask(letters))
+		}
+	}
+}
+
+func BenchmarkLoadJSON(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := LoadJSON(wordsPath); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestLetterCountsSpells(t *testing.T) {
+	counts := LetterCounts("AAbt")
+
+	for word, want := range map[string]bool{
+		"tab":  true,
+		"abba": false,
+		"baa":  true,
+		"taba": true,
+		"tabs": false,
+		"bat!": false,
+	} {
+		if got := counts.Spells(word); got != want {
+			t.Errorf("%s: got %v, want %v", word, got, want)
+		}
+	}
+}
+
+func TestRandomLetters(t *testing.T) {
+	index := NewIndex([]string{"tab", "bat", "abs", "cat", "act", "tac", "dog"})
+
+	letters, err := RandomLetters(index, 3, func(letters string) (bool, error) {
+		words, err := index.Find(letters, true)
+		return len(words) >= 3, err
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lettersMask(letters) != lettersMask("act") {
+		t.Errorf("got %q, want the letters of act", letters)
+	}
+
+	_, err = RandomLetters(index, 3, func(letters string) (bool, error) {
+		return false, nil
+	})
+
+	if err == nil {
+		t.Error("expected an error when no letter set fits")
+	}
+}
+
+type stubDefiner map[string]string
+
+func (stub stubDefiner) Define(word string) (string, bool) {
+	definition, ok := stub[word]
+	return definition, ok
+}
+
+func TestDefine(t *testing.T) {
+	SetDefiner(stubDefiner{"tab": "a small flap"})
+	defer SetDefiner(Lexicon{})
+
+	if got, ok := Define("tab"); !ok || got != "a small flap" {
+		t.Errorf("got %q, %v", got, ok)
+	}
+
+	if _, ok := Define("bat"); ok {
+		t.Error("expected no definition for bat")
+	}
+}
+
+func TestLoadLexicon(t *testing.T) {
+	lexicon, err := LoadLexicon("../definitions.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for word, definition := range lexicon {
+		if word != strings.ToLower(word) || definition == "" {
+			t.Errorf("bad entry %q: %q", word, definition)
+		}
+	}
+}
@@ -2,8 +2,8 @@ package util
 
 import (
 	"context"
-	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -23,7 +23,22 @@ type GamesStats struct {
 	Amount int    `bson:"amount"`
 }
 
+var (
+	mongoClient *mongo.Client
+	mongoOnce   sync.Once
+)
+
+// MongoClient returns the process wide client, connecting the first time it
+// is called.
 func MongoClient() *mongo.Client {
+	mongoOnce.Do(func() {
+		mongoClient = connect()
+	})
+
+	return mongoClient
+}
+
+func connect() *mongo.Client {
 	host := os.Getenv("MONGO_HOST")
 	user := os.Getenv("MONGO_USER")
 	password := os.Getenv("MONGO_PWD")
@@ -51,47 +66,120 @@ func MongoClient() *mongo.Client {
 	return client
 }
 
-func GetDocs(client *mongo.Collection, filter bson.E) *mongo.Cursor {
-	docs, err := client.Find(context.TODO(), bson.D{filter}, options.Find().SetLimit(10))
-
-	if err != nil {
-		fmt.Printf("%+v", err)
-		return nil
-	}
+// MonthRange returns the first and last instant of the month containing date.
+func MonthRange(date time.Time) (time.Time, time.Time) {
+	firstDay := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.Local)
+	lastDay := firstDay.AddDate(0, 1, 0).Add(time.Nanosecond * -1)
 
-	return docs
+	return firstDay, lastDay
 }
 
-func AggregateLeaders(date time.Time, sortKey string, limit int) []bson.M {
-	leadersColl := MongoClient().Database("slack").Collection("leaders")
-	firstDay := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.Local)
-	lastDay := firstDay.AddDate(0, 1, 0).Add(time.Nanosecond * -1)
+// AggregateLeaders ranks one of the monthly leader lists ("solved",
+// "created" or "usersSolved") for the month containing date. A limit of -1
+// returns everyone.
+func AggregateLeaders(db *mongo.Database, date time.Time, sortKey string, limit int) ([]GamesStats, error) {
+	leadersColl := db.Collection("leaders")
+	firstDay, lastDay := MonthRange(date)
 
 	aggFilter := []bson.M{{
-		"$sort": bson.M{
-			sortKey: -1,
-		},
-	}, {
 		"$match": bson.M{
 			"date": bson.M{
 				"$gte": firstDay,
 				"$lte": lastDay,
 			},
 		},
+	}, {
+		"$unwind": "$" + sortKey,
+	}, {
+		"$replaceRoot": bson.M{
+			"newRoot": "$" + sortKey,
+		},
+	}, {
+		"$sort": bson.M{
+			"amount": -1,
+		},
 	}}
 
 	if limit != -1 {
 		aggFilter = append(aggFilter, bson.M{"$limit": limit})
 	}
 
-	var solved []bson.M
+	var leaders []GamesStats
 
-	solvedAgg, err := leadersColl.Aggregate(context.TODO(), aggFilter)
+	leadersAgg, err := leadersColl.Aggregate(context.TODO(), aggFilter)
 
 	if err != nil {
+		return nil, err
+	}
+
+	err = leadersAgg.All(context.TODO(), &leaders)
+	return leaders, err
+}
+
+func countBy(coll *mongo.Collection, pipeline []bson.M) ([]GamesStats, error) {
+	pipeline = append(pipeline, bson.M{
+		"$sort": bson.M{"amount": -1},
+	})
+
+	cursor, err := coll.Aggregate(context.TODO(), pipeline)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []GamesStats
+	err = cursor.All(context.TODO(), &stats)
+	return stats, err
+}
+
+// RollupLeaders works out, for the month containing date, how many games
+// each user solved, how many each user created and how many different people
+// solved each creator's games, and saves them as that month's leaders
+// document.
+func RollupLeaders(db *mongo.Database, date time.Time) error {
+	games := db.Collection("games")
+	firstDay, lastDay := MonthRange(date)
+	inMonth := bson.M{"$gte": firstDay, "$lte": lastDay}
+
+	created, err := countBy(games, []bson.M{
+		{"$match": bson.M{"date": inMonth}},
+		{"$group": bson.M{"_id": "$user", "amount": bson.M{"$sum": 1}}},
+		{"$project": bson.M{"_id": 0, "user": "$_id", "amount": 1}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	solved, err := countBy(games, []bson.M{
+		{"$unwind": "$leaderboard"},
+		{"$match": bson.M{"leaderboard.date": inMonth}},
+		{"$group": bson.M{"_id": "$leaderboard.user", "amount": bson.M{"$sum": 1}}},
+		{"$project": bson.M{"_id": 0, "user": "$_id", "amount": 1}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	usersSolved, err := countBy(games, []bson.M{
+		{"$unwind": "$leaderboard"},
+		{"$match": bson.M{"leaderboard.date": inMonth}},
+		{"$group": bson.M{"_id": "$user", "solvers": bson.M{"$addToSet": "$leaderboard.user"}}},
+		{"$project": bson.M{"_id": 0, "user": "$_id", "amount": bson.M{"$size": "$solvers"}}},
+	})
+
+	if err != nil {
+		return err
+	}
 
+	leaders := Leaders{
+		Date:        firstDay,
+		Solved:      solved,
+		Created:     created,
+		UsersSolved: usersSolved,
 	}
 
-	solvedAgg.All(context.TODO(), &solved)
-	return solved
+	_, err = db.Collection("leaders").ReplaceOne(context.TODO(), bson.M{"date": firstDay}, leaders, options.Replace().SetUpsert(true))
+	return err
 }